@@ -0,0 +1,60 @@
+// Package cee extracts the JSON objects that follow the @cee cookie in the MSG part of syslog messages.
+//
+// This is the CEE (Common Event Expression) syslog transport, also known as Lumberjack,
+// that rsyslog's mmjsonparse module and other loggers use:
+//
+//	<13>1 2003-10-11T22:14:15.003Z host app - - - @cee: {"event":"login","user":{"name":"joe"}}
+package cee
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	cookie  = "@cee:"
+	utf8BOM = "\xEF\xBB\xBF"
+)
+
+var (
+	// ErrNoCookie is returned for inputs not starting with the @cee cookie.
+	ErrNoCookie = errors.New("expecting the @cee: cookie")
+	// ErrObject is returned when the cookie is not followed by a single JSON object.
+	ErrObject = errors.New("expecting a JSON object after the @cee: cookie")
+)
+
+// Parse decodes the JSON object following the @cee cookie.
+//
+// Leading spaces and UTF-8 byte order mark are ignored.
+// Numbers are decoded as json.Number, so that integers do not lose precision.
+func Parse(input []byte) (map[string]interface{}, error) {
+	s := strings.TrimLeft(strings.TrimPrefix(string(input), utf8BOM), " ")
+	if !strings.HasPrefix(s, cookie) {
+		return nil, ErrNoCookie
+	}
+
+	return decodeObject(s[len(cookie):])
+}
+
+func decodeObject(s string) (map[string]interface{}, error) {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+
+	var out map[string]interface{}
+	if err := d.Decode(&out); err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return nil, ErrObject
+	}
+	// Nothing but spaces can follow the object
+	rest, _ := ioutil.ReadAll(d.Buffered())
+	if len(bytes.TrimSpace(rest)) > 0 || d.More() {
+		return nil, ErrObject
+	}
+
+	return out, nil
+}
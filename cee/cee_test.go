@@ -0,0 +1,62 @@
+package cee
+
+import (
+	"encoding/json"
+	"testing"
+
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input  string
+		fields map[string]interface{}
+		err    error
+	}{
+		{
+			`@cee: {"msg":"hello","pid":42}`,
+			map[string]interface{}{"msg": "hello", "pid": json.Number("42")},
+			nil,
+		},
+		{
+			`@cee:{"user":{"name":"joe","groups":["adm","wheel"]},"ok":true,"gone":null}`,
+			map[string]interface{}{
+				"user": map[string]interface{}{"name": "joe", "groups": []interface{}{"adm", "wheel"}},
+				"ok":   true,
+				"gone": nil,
+			},
+			nil,
+		},
+		{
+			"\xEF\xBB\xBF  @cee: {\"id\":18446744073709551615}  \n",
+			map[string]interface{}{"id": json.Number("18446744073709551615")},
+			nil,
+		},
+		{`@cee: {}`, map[string]interface{}{}, nil},
+		{`{"msg":"hello"}`, nil, ErrNoCookie},
+		{`@cee {"msg":"hello"}`, nil, ErrNoCookie},
+		{`@cee: ["msg"]`, nil, nil},
+		{`@cee: null`, nil, ErrObject},
+		{`@cee: {"msg":"hello"} trailing`, nil, ErrObject},
+		{`@cee: {"msg":"hello"}{"other":1}`, nil, ErrObject},
+		{`@cee: {"msg":`, nil, nil},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc.input, 50), func(t *testing.T) {
+			t.Parallel()
+
+			fields, err := Parse([]byte(tc.input))
+			if tc.fields == nil && tc.err == nil {
+				// Errors of the JSON decoder
+				assert.Error(t, err)
+				assert.Nil(t, fields)
+				return
+			}
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.fields, fields)
+		})
+	}
+}
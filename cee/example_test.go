@@ -0,0 +1,48 @@
+package cee
+
+import (
+	"github.com/davecgh/go-spew/spew"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+)
+
+func output(out interface{}) {
+	spew.Config.DisableCapacities = true
+	spew.Config.DisablePointerAddresses = true
+	spew.Dump(out)
+}
+
+func Example() {
+	i := []byte(`<13>1 2003-10-11T22:14:15.003Z host app - - - @cee: {"user":{"name":"joe"}}`)
+	p := NewMachine(rfc5424.NewParser())
+	m, _ := p.Parse(i)
+	output(m.(*Message).Fields)
+	// Output:
+	// (map[string]interface {}) (len=1) {
+	//  (string) (len=4) "user": (map[string]interface {}) (len=1) {
+	//   (string) (len=4) "name": (string) (len=3) "joe"
+	//  }
+	// }
+}
+
+func Example_rfc3164() {
+	i := []byte(`<13>Oct 11 22:14:15 host @cee: {"event":"login"}`)
+	p := NewMachine(rfc3164.NewParser())
+	m, _ := p.Parse(i)
+	output(m.(*Message).Fields)
+	// Output:
+	// (map[string]interface {}) (len=1) {
+	//  (string) (len=5) "event": (string) (len=5) "login"
+	// }
+}
+
+func Example_malformed() {
+	i := []byte(`<13>1 2003-10-11T22:14:15.003Z host app - - - @cee: {"user":`)
+	p := NewMachine(rfc5424.NewParser())
+	m, err := p.Parse(i)
+	output(err)
+	output(m.(*Message).Err)
+	// Output:
+	// (interface {}) <nil>
+	// (*errors.errorString)(unexpected EOF)
+}
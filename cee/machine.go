@@ -0,0 +1,49 @@
+package cee
+
+import (
+	"github.com/influxdata/go-syslog/v3"
+)
+
+// Message represents a syslog message whose MSG part has been decoded.
+//
+// Fields is nil when the MSG part does not start with the @cee cookie,
+// while Err contains the decoding error when it does but the JSON object is malformed.
+type Message struct {
+	syslog.Decoded
+	Fields map[string]interface{}
+	Err    error
+}
+
+// FromMessage decodes the JSON object following the @cee cookie in the MSG part of the syslog message (see syslog.Payload).
+func FromMessage(m syslog.Message) (map[string]interface{}, error) {
+	body, ok := syslog.Payload(m, cookie)
+	if !ok {
+		return nil, ErrNoCookie
+	}
+
+	return Parse([]byte(body))
+}
+
+// NewMachine wraps the machine so that it also decodes the @cee JSON objects (see syslog.NewDecodingMachine).
+//
+// The messages it returns are *Message instances.
+// The errors of the decoding never fail the parsing, they are in the Err field of the messages instead.
+func NewMachine(m syslog.Machine) syslog.Machine {
+	return syslog.NewDecodingMachine(m, decode)
+}
+
+// Listener wraps the listener so that it receives decoded messages (see syslog.NewDecodingListener).
+func Listener(l syslog.ParserListener) syslog.ParserListener {
+	return syslog.NewDecodingListener(l, decode)
+}
+
+func decode(a syslog.Accessor) (syslog.Message, error) {
+	out := &Message{Decoded: syslog.Decoded{Accessor: a}}
+	fields, err := FromMessage(a)
+	if err != ErrNoCookie {
+		out.Fields = fields
+		out.Err = err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,78 @@
+package cee
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/nontransparent"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMessage(t *testing.T) {
+	m, err := rfc5424.NewParser().Parse([]byte(`<13>1 - host app - - - @cee: {"a":1}`))
+	assert.Nil(t, err)
+	fields, err := FromMessage(m)
+	assert.Nil(t, err)
+	assert.Equal(t, json.Number("1"), fields["a"])
+
+	m, err = rfc3164.NewParser().Parse([]byte(`<13>Jan  1 00:00:00 host app[1]: @cee:{"a":1}`))
+	assert.Nil(t, err)
+	fields, err = FromMessage(m)
+	assert.Nil(t, err)
+	assert.Equal(t, json.Number("1"), fields["a"])
+
+	// RFC3164 messages with the @cee tag
+	m, err = rfc3164.NewParser().Parse([]byte(`<13>Jan  1 00:00:00 host @cee: {"a":1}`))
+	assert.Nil(t, err)
+	fields, err = FromMessage(m)
+	assert.Nil(t, err)
+	assert.Equal(t, json.Number("1"), fields["a"])
+
+	m, err = rfc5424.NewParser().Parse([]byte(`<13>1 - host app - - -`))
+	assert.Nil(t, err)
+	_, err = FromMessage(m)
+	assert.Equal(t, ErrNoCookie, err)
+}
+
+func TestMachine(t *testing.T) {
+	p := NewMachine(rfc5424.NewParser())
+
+	m, err := p.Parse([]byte(`<13>1 - host app - - - @cee: {"a":"b"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "b"}, m.(*Message).Fields)
+	assert.Nil(t, m.(*Message).Err)
+	assert.Equal(t, "host", *m.(*Message).GetHostname())
+	assert.IsType(t, &rfc5424.SyslogMessage{}, m.(*Message).Original())
+
+	// No cookie
+	m, err = p.Parse([]byte(`<13>1 - host app - - - {"a":"b"}`))
+	assert.Nil(t, err)
+	assert.Nil(t, m.(*Message).Fields)
+	assert.Nil(t, m.(*Message).Err)
+
+	// Malformed JSON objects do not fail the parsing
+	m, err = p.Parse([]byte(`<13>1 - host app - - - @cee: {"a":`))
+	assert.Nil(t, err)
+	assert.Nil(t, m.(*Message).Fields)
+	assert.Error(t, m.(*Message).Err)
+	assert.Equal(t, `@cee: {"a":`, *m.(*Message).GetMessage())
+}
+
+func TestListener(t *testing.T) {
+	input := "<13>1 - host app - - - @cee: {\"a\":\"b\"}\n<13>1 - host app - - - @cee: x\n"
+	var results []*syslog.Result
+	p := nontransparent.NewParser(syslog.WithListener(Listener(func(res *syslog.Result) {
+		results = append(results, res)
+	})))
+	p.Parse(strings.NewReader(input))
+
+	assert.Len(t, results, 2)
+	assert.Nil(t, results[0].Error)
+	assert.Equal(t, "b", results[0].Message.(*Message).Fields["a"])
+	assert.Nil(t, results[1].Error)
+	assert.Error(t, results[1].Message.(*Message).Err)
+}
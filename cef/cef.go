@@ -0,0 +1,282 @@
+// Package cef decodes ArcSight Common Event Format (CEF) events carried in the MSG part of syslog messages.
+//
+// A CEF event is made of a header and an extension:
+//
+//	CEF:Version|Device Vendor|Device Product|Device Version|Device Event Class ID|Name|Severity|Extension
+//
+// Header fields escape pipes and backslashes with a backslash,
+// while the extension is made of space separated key=value pairs whose values escape equal signs, backslashes, and new lines.
+package cef
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	prefix  = "CEF:"
+	utf8BOM = "\xEF\xBB\xBF"
+)
+
+var (
+	// ErrNotCEF is returned for inputs not starting with the CEF prefix.
+	ErrNotCEF = errors.New("expecting a CEF event (starting with CEF:)")
+	// ErrHeader is returned for malformed CEF headers.
+	ErrHeader = errors.New("expecting a CEF header with version, device vendor, device product, device version, device event class id, name, and severity separated by pipes")
+	// ErrSeverity is returned for CEF headers with an invalid severity.
+	ErrSeverity = errors.New("expecting a CEF severity from 0 to 10, or one of Unknown, Low, Medium, High, Very-High")
+	// ErrExtension is returned for malformed CEF extensions.
+	ErrExtension = errors.New("expecting CEF extension key=value pairs separated by spaces")
+)
+
+// Event represents a CEF event.
+type Event struct {
+	Version            int
+	DeviceVendor       string
+	DeviceProduct      string
+	DeviceVersion      string
+	DeviceEventClassID string
+	Name               string
+	Severity           string
+	Extensions         map[string]string
+}
+
+// Parse decodes a CEF event.
+//
+// Leading spaces and UTF-8 byte order mark are ignored.
+func Parse(input []byte) (*Event, error) {
+	s := strings.TrimLeft(strings.TrimPrefix(string(input), utf8BOM), " ")
+	if !strings.HasPrefix(s, prefix) {
+		return nil, ErrNotCEF
+	}
+	s = s[len(prefix):]
+
+	// The extension can contain unescaped pipes
+	fields := make([]string, 0, 8)
+	start := 0
+	for i := 0; i < len(s) && len(fields) < 7; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '|':
+			fields = append(fields, unescapeHeader(s[start:i]))
+			start = i + 1
+		}
+	}
+	if len(fields) < 7 {
+		return nil, ErrHeader
+	}
+
+	version, err := strconv.Atoi(fields[0])
+	if err != nil || version < 0 {
+		return nil, ErrHeader
+	}
+	e := &Event{
+		Version:            version,
+		DeviceVendor:       fields[1],
+		DeviceProduct:      fields[2],
+		DeviceVersion:      fields[3],
+		DeviceEventClassID: fields[4],
+		Name:               fields[5],
+		Severity:           fields[6],
+	}
+	if e.SeverityLevel() == "" {
+		return nil, ErrSeverity
+	}
+	if e.Extensions, err = parseExtension(s[start:]); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// SeverityLevel returns the severity as one of Unknown, Low (0-3), Medium (4-6), High (7-8), and Very-High (9-10).
+//
+// It returns an empty string for invalid severities.
+func (e *Event) SeverityLevel() string {
+	n, err := strconv.Atoi(e.Severity)
+	if err != nil {
+		for _, level := range []string{"Unknown", "Low", "Medium", "High", "Very-High"} {
+			if strings.EqualFold(e.Severity, level) {
+				return level
+			}
+		}
+		return ""
+	}
+
+	switch {
+	case n < 0 || n > 10:
+		return ""
+	case n <= 3:
+		return "Low"
+	case n <= 6:
+		return "Medium"
+	case n <= 8:
+		return "High"
+	}
+	return "Very-High"
+}
+
+// SourceAddress returns the value of the src extension, nil when absent or invalid.
+func (e *Event) SourceAddress() net.IP {
+	return net.ParseIP(e.Extensions["src"])
+}
+
+// DestinationAddress returns the value of the dst extension, nil when absent or invalid.
+func (e *Event) DestinationAddress() net.IP {
+	return net.ParseIP(e.Extensions["dst"])
+}
+
+// SourcePort returns the value of the spt extension.
+func (e *Event) SourcePort() (int, bool) {
+	return e.port("spt")
+}
+
+// DestinationPort returns the value of the dpt extension.
+func (e *Event) DestinationPort() (int, bool) {
+	return e.port("dpt")
+}
+
+// ReceiptTime returns the value of the rt extension,
+// either in milliseconds since epoch or in one of the "MMM dd yyyy HH:mm:ss" formats.
+func (e *Event) ReceiptTime() (time.Time, bool) {
+	v, ok := e.Extensions["rt"]
+	if !ok {
+		return time.Time{}, false
+	}
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC(), true
+	}
+	for _, layout := range []string{"Jan 02 2006 15:04:05.000 MST", "Jan 02 2006 15:04:05 MST", "Jan 02 2006 15:04:05.000", "Jan 02 2006 15:04:05"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// CustomStrings returns the values of the custom string extensions (cs1 to cs6) by their labels (cs1Label to cs6Label).
+//
+// The values without a label are returned by their key.
+func (e *Event) CustomStrings() map[string]string {
+	out := map[string]string{}
+	for i := 1; i <= 6; i++ {
+		key := "cs" + strconv.Itoa(i)
+		v, ok := e.Extensions[key]
+		if !ok {
+			continue
+		}
+		if label, ok := e.Extensions[key+"Label"]; ok && label != "" {
+			key = label
+		}
+		out[key] = v
+	}
+
+	return out
+}
+
+func (e *Event) port(key string) (int, bool) {
+	n, err := strconv.Atoi(e.Extensions[key])
+	if err != nil || n < 0 || n > 65535 {
+		return 0, false
+	}
+
+	return n, true
+}
+
+func unescapeHeader(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && (s[i+1] == '|' || s[i+1] == '\\') {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+func unescapeValue(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case '=', '\\':
+				i++
+			case 'n':
+				i++
+				b.WriteByte('\n')
+				continue
+			case 'r':
+				i++
+				b.WriteByte('\r')
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+func isKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '.' || c == '-' || c == '[' || c == ']'
+}
+
+// parseExtension splits the extension into its pairs.
+//
+// Since values can contain spaces, and sometimes unescaped equal signs, a value ends where a space followed by a key and an equal sign starts.
+func parseExtension(s string) (map[string]string, error) {
+	out := map[string]string{}
+	s = strings.TrimRight(s, " \r\n")
+
+	type key struct {
+		start, end int
+	}
+	var keys []key
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '=':
+			k := i
+			for k > 0 && isKeyChar(s[k-1]) {
+				k--
+			}
+			if k < i && (k == 0 || s[k-1] == ' ') {
+				keys = append(keys, key{k, i})
+			}
+		}
+	}
+
+	if len(keys) == 0 {
+		if strings.TrimSpace(s) != "" {
+			return nil, ErrExtension
+		}
+		return out, nil
+	}
+	if strings.TrimLeft(s[:keys[0].start], " ") != "" {
+		return nil, ErrExtension
+	}
+	for i, k := range keys {
+		end := len(s)
+		if i+1 < len(keys) {
+			end = keys[i+1].start - 1
+		}
+		out[s[k.start:k.end]] = unescapeValue(strings.TrimRight(s[k.end+1:end], " "))
+	}
+
+	return out, nil
+}
@@ -0,0 +1,106 @@
+package cef
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input string
+		event *Event
+		err   error
+	}{
+		{
+			`CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232`,
+			&Event{0, "Security", "threatmanager", "1.0", "100", "worm successfully stopped", "10", map[string]string{"src": "10.0.0.1", "dst": "2.1.2.2", "spt": "1232"}},
+			nil,
+		},
+		{
+			`CEF:1|Vendor|Product|2.0|sig|name with | unescaped pipe|Low|`,
+			nil,
+			ErrSeverity,
+		},
+		{
+			`CEF:1|Vendor\|Inc|Product\\1|2.0|sig|name|Very-High|`,
+			&Event{1, "Vendor|Inc", `Product\1`, "2.0", "sig", "name", "Very-High", map[string]string{}},
+			nil,
+		},
+		{
+			"\xEF\xBB\xBF  CEF:0|V|P|1|s|n|3|msg=line one\\nline two act=blocked a\\=b cs1=x",
+			&Event{0, "V", "P", "1", "s", "n", "3", map[string]string{"msg": "line one\nline two", "act": "blocked a=b", "cs1": "x"}},
+			nil,
+		},
+		{
+			`CEF:0|V|P|1|s|n|3|request=https://example.com/?a=b&c=d  suser=bob|admin `,
+			&Event{0, "V", "P", "1", "s", "n", "3", map[string]string{"request": "https://example.com/?a=b&c=d", "suser": "bob|admin"}},
+			nil,
+		},
+		{
+			`CEF:0|V|P|1|s|n|3|path=C:\\Windows\\ ad.key=1`,
+			&Event{0, "V", "P", "1", "s", "n", "3", map[string]string{"path": `C:\Windows\`, "ad.key": "1"}},
+			nil,
+		},
+		{`LEEF:1.0|V|P|1|s|`, nil, ErrNotCEF},
+		{`CEF:0|V|P|1|s|n|3`, nil, ErrHeader},
+		{`CEF:x|V|P|1|s|n|3|`, nil, ErrHeader},
+		{`CEF:0|V|P|1|s|n|11|`, nil, ErrSeverity},
+		{`CEF:0|V|P|1|s|n|3|no pairs`, nil, ErrExtension},
+		{`CEF:0|V|P|1|s|n|3|garbage src=1.2.3.4`, nil, ErrExtension},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc.input, 50), func(t *testing.T) {
+			t.Parallel()
+
+			e, err := Parse([]byte(tc.input))
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.event, e)
+			} else {
+				assert.Nil(t, e)
+			}
+		})
+	}
+}
+
+func TestSeverityLevel(t *testing.T) {
+	levels := map[string]string{
+		"0": "Low", "3": "Low", "4": "Medium", "6": "Medium", "7": "High", "8": "High", "9": "Very-High", "10": "Very-High",
+		"unknown": "Unknown", "HIGH": "High", "-1": "", "11": "", "Critical": "",
+	}
+	for severity, level := range levels {
+		assert.Equal(t, level, (&Event{Severity: severity}).SeverityLevel(), severity)
+	}
+}
+
+func TestTypedExtensions(t *testing.T) {
+	e, err := Parse([]byte(`CEF:0|V|P|1|s|n|3|src=10.0.0.1 dst=::1 spt=1232 dpt=99999 rt=1541066730123 cs1=a cs1Label=first cs2=b`))
+	assert.Nil(t, err)
+
+	assert.Equal(t, net.ParseIP("10.0.0.1"), e.SourceAddress())
+	assert.Equal(t, net.ParseIP("::1"), e.DestinationAddress())
+	port, ok := e.SourcePort()
+	assert.True(t, ok)
+	assert.Equal(t, 1232, port)
+	_, ok = e.DestinationPort()
+	assert.False(t, ok)
+	rt, ok := e.ReceiptTime()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2018, 11, 1, 10, 5, 30, 123000000, time.UTC), rt)
+	assert.Equal(t, map[string]string{"first": "a", "cs2": "b"}, e.CustomStrings())
+
+	e.Extensions["rt"] = "Nov 01 2018 10:05:30"
+	rt, ok = e.ReceiptTime()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2018, 11, 1, 10, 5, 30, 0, time.UTC), rt)
+
+	delete(e.Extensions, "rt")
+	_, ok = e.ReceiptTime()
+	assert.False(t, ok)
+}
@@ -0,0 +1,32 @@
+package cef
+
+import (
+	"github.com/davecgh/go-spew/spew"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+)
+
+func output(out interface{}) {
+	spew.Config.DisableCapacities = true
+	spew.Config.DisablePointerAddresses = true
+	spew.Dump(out)
+}
+
+func Example() {
+	i := []byte(`<134>1 2018-11-01T10:05:30Z host - - - - CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1`)
+	p := NewMachine(rfc5424.NewParser())
+	m, _ := p.Parse(i)
+	output(m.(*Message).Event)
+	// Output:
+	// (*cef.Event)({
+	//  Version: (int) 0,
+	//  DeviceVendor: (string) (len=8) "Security",
+	//  DeviceProduct: (string) (len=13) "threatmanager",
+	//  DeviceVersion: (string) (len=3) "1.0",
+	//  DeviceEventClassID: (string) (len=3) "100",
+	//  Name: (string) (len=25) "worm successfully stopped",
+	//  Severity: (string) (len=2) "10",
+	//  Extensions: (map[string]string) (len=1) {
+	//   (string) (len=3) "src": (string) (len=8) "10.0.0.1"
+	//  }
+	// })
+}
@@ -0,0 +1,49 @@
+package cef
+
+import (
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMessage(t *testing.T) {
+	m, err := rfc5424.NewParser().Parse([]byte(`<134>1 - host - - - - CEF:0|V|P|1|s|n|3|src=10.0.0.1`))
+	assert.Nil(t, err)
+	e, err := FromMessage(m)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", e.Extensions["src"])
+
+	// RFC3164 messages with the CEF tag
+	m, err = rfc3164.NewParser().Parse([]byte(`<134>Feb 14 19:04:54 host CEF:0|V|P|1|s|n|3|src=10.0.0.1`))
+	assert.Nil(t, err)
+	e, err = FromMessage(m)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", e.Extensions["src"])
+
+	m, err = rfc5424.NewParser().Parse([]byte(`<134>1 - host - - - -`))
+	assert.Nil(t, err)
+	_, err = FromMessage(m)
+	assert.Equal(t, ErrNotCEF, err)
+}
+
+func TestMachine(t *testing.T) {
+	p := NewMachine(rfc5424.NewParser())
+
+	m, err := p.Parse([]byte(`<134>1 - host - - - - CEF:0|V|P|1|s|n|3|src=10.0.0.1`))
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", m.(*Message).Event.Extensions["src"])
+	assert.Equal(t, "host", *m.(*Message).GetHostname())
+	assert.IsType(t, &rfc5424.SyslogMessage{}, m.(*Message).Original())
+
+	// Not a CEF event
+	m, err = p.Parse([]byte(`<134>1 - host - - - - hello`))
+	assert.Nil(t, err)
+	assert.Nil(t, m.(*Message).Event)
+
+	// Malformed CEF event
+	m, err = p.Parse([]byte(`<134>1 - host - - - - CEF:0|V|P`))
+	assert.Equal(t, ErrHeader, err)
+	assert.Nil(t, m.(*Message).Event)
+}
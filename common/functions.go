@@ -29,16 +29,21 @@ func RemoveBytes(data []byte, positions []int, offset int) []byte {
 
 // EscapeBytes adds a backslash to \, ], " characters.
 func EscapeBytes(value string) string {
-	res := ""
-	for i, c := range value {
-		// todo(leodido): generalize byte codes (the function should ideally accept a byte slice containing byte codes to escape)
-		if c == 92 || c == 93 || c == 34 {
-			res += `\`
+	return string(AppendEscaped(make([]byte, 0, len(value)), value))
+}
+
+// AppendEscaped appends value to dst adding a backslash to \, ], " characters, and returns the extended buffer.
+func AppendEscaped(dst []byte, value string) []byte {
+	last := 0
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c == '\\' || c == ']' || c == '"' {
+			dst = append(dst, value[last:i]...)
+			dst = append(dst, '\\')
+			last = i
 		}
-		res += string(value[i])
 	}
 
-	return res
+	return append(dst, value[last:]...)
 }
 
 // InBetween tells whether value is into [min, max] range.
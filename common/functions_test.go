@@ -30,3 +30,19 @@ func TestAllDigits(t *testing.T) {
 	res := UnsafeUTF8DecimalCodePointsToInt(slice)
 	assert.Equal(t, 1234567890, res)
 }
+
+func TestEscapeBytes(t *testing.T) {
+	assert.Equal(t, "", EscapeBytes(""))
+	assert.Equal(t, "value", EscapeBytes("value"))
+	assert.Equal(t, `\"a\\b\]c`, EscapeBytes(`"a\b]c`))
+}
+
+func TestAppendEscaped(t *testing.T) {
+	assert.Equal(t, `x=\]\]`, string(AppendEscaped([]byte("x="), "]]")))
+}
+
+// Multi-byte characters used to be escaped byte by byte, turning them into mojibake (eg., "⌘" into "â\u008c\u0098")
+func TestEscapeMultiByteCharacters(t *testing.T) {
+	assert.Equal(t, `⌘ κόσμε \]`, EscapeBytes(`⌘ κόσμε ]`))
+	assert.Equal(t, "àèìòù", string(AppendEscaped(nil, "àèìòù")))
+}
@@ -0,0 +1,282 @@
+// Package convert provides conversions between RFC3164 and RFC5424 syslog messages.
+//
+// Conversions never fail because of information that does not fit the target format:
+// they return the converted message together with a Losses error reporting what has been truncated or dropped.
+// Callers that cannot accept lossy conversions can reject the converted message when the error is not nil.
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3/common"
+	"github.com/influxdata/go-syslog/v3/logfmt"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+)
+
+const (
+	// maxTag is the maximum length of a RFC3164 TAG.
+	maxTag = 32
+	// maxHostname is the maximum length of a RFC5424 HOSTNAME.
+	maxHostname = 255
+	// maxAppname is the maximum length of a RFC5424 APP-NAME.
+	maxAppname = 48
+	// maxProcID is the maximum length of a RFC5424 PROCID.
+	maxProcID = 128
+)
+
+// ErrPriority is returned when the message to convert does not have a valid priority.
+var ErrPriority = errors.New("expecting a message with a priority value in the range 1-191 or equal to 0")
+
+// Loss describes a piece of information that a conversion has truncated or dropped.
+type Loss struct {
+	// Field is the name of the field of the source message.
+	Field  string
+	Reason string
+}
+
+// String returns a textual representation of the loss.
+func (l Loss) String() string {
+	return l.Field + " " + l.Reason
+}
+
+// Losses represents all the pieces of information that a conversion has truncated or dropped.
+type Losses []Loss
+
+// Error joins the losses.
+func (l Losses) Error() string {
+	msgs := make([]string, len(l))
+	for i, loss := range l {
+		msgs[i] = loss.String()
+	}
+	return "lossy conversion: " + strings.Join(msgs, "; ")
+}
+
+func (l Losses) err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// Option represents an option of the conversions.
+type Option func(*converter)
+
+type converter struct {
+	promote bool
+	pairsID string
+}
+
+// WithKeyValues promotes the key=value pairs of the message (see package logfmt) to a STRUCTURED-DATA element with the given SD-ID.
+//
+// The MSG part is kept as is, while the element is not added when there are no pairs.
+// When the SD-ID is not valid (eg., "kv@32473" is) the pairs are not promoted and the conversion reports their loss.
+func WithKeyValues(id string) Option {
+	return func(c *converter) {
+		c.promote = true
+		c.pairsID = id
+	}
+}
+
+// ToRFC5424 converts a RFC3164 syslog message to a RFC5424 one, with version 1.
+//
+// The TAG becomes the APP-NAME, the CONTENT becomes the PROCID, and the message (without the TAG) becomes the MSG.
+// The parts that RFC3164 lacks (ie., MSGID and STRUCTURED-DATA) are nil values.
+// The HOSTNAME, the APP-NAME, and the PROCID exceeding the RFC5424 limits are truncated,
+// or dropped when containing characters other than printable US-ASCII ones (eg., a CONTENT with spaces).
+// The timestamp is truncated to microseconds.
+// The keys that are not valid PARAM-NAMEs and the values of the repeated keys but the last one are dropped when promoting key=value pairs (see WithKeyValues).
+func ToRFC5424(m *rfc3164.SyslogMessage, options ...Option) (*rfc5424.SyslogMessage, error) {
+	if m == nil || m.Priority == nil || !common.ValidPriority(*m.Priority) {
+		return nil, ErrPriority
+	}
+	c := &converter{}
+	for _, opt := range options {
+		opt(c)
+	}
+
+	var losses Losses
+	out := &rfc5424.SyslogMessage{}
+	out.ComputeFromPriority(*m.Priority)
+	out.Version = 1
+
+	if m.Timestamp != nil {
+		t := m.Timestamp.Truncate(time.Microsecond)
+		if !t.Equal(*m.Timestamp) {
+			losses = append(losses, Loss{"timestamp", "truncated to microseconds"})
+		}
+		out.Timestamp = &t
+	}
+	out.Hostname = fit("hostname", m.Hostname, maxHostname, &losses)
+	out.Appname = fit("appname", m.Appname, maxAppname, &losses)
+	out.ProcID = fit("procid", m.ProcID, maxProcID, &losses)
+	out.Message = copyString(m.Message)
+	if c.promote && m.Message != nil {
+		out.StructuredData = promote(c.pairsID, logfmt.Parse([]byte(*m.Message)), &losses)
+	}
+
+	return out, losses.err()
+}
+
+// promote returns the structured data made of an element containing the pairs, nil when there are no valid pairs.
+func promote(id string, pairs logfmt.Pairs, losses *Losses) *map[string]map[string]string {
+	if len(pairs) > 0 && !isSdName(id) {
+		*losses = append(*losses, Loss{"message", fmt.Sprintf("key=value pairs not promoted since %q is not a valid SD-ID", id)})
+		return nil
+	}
+	params := map[string]string{}
+	for _, p := range pairs {
+		if !isSdName(p.Key) {
+			*losses = append(*losses, Loss{"message", fmt.Sprintf("key %q not promoted since it is not a valid PARAM-NAME", p.Key)})
+			continue
+		}
+		// Duplicate keys: the last one wins
+		if v, ok := params[p.Key]; ok {
+			*losses = append(*losses, Loss{"message", fmt.Sprintf("value %q of key %q not promoted since the key repeats", v, p.Key)})
+		}
+		params[p.Key] = p.Value
+	}
+	if len(params) == 0 {
+		return nil
+	}
+
+	return &map[string]map[string]string{id: params}
+}
+
+// ToRFC3164 converts a RFC5424 syslog message to a RFC3164 one.
+//
+// The MSG part is made of the APP-NAME (as TAG), of the PROCID (as CONTENT within square brackets), and of the message.
+// The APP-NAME is truncated to 32 characters (or to its first character that is not valid in a TAG),
+// while the MSGID and the STRUCTURED-DATA are dropped, since RFC3164 has no place for them.
+// The timestamp is truncated to seconds.
+// Its year and its timezone offset are reported as lost, since the RFC3164 Mmm dd hh:mm:ss form has no place for them,
+// unless they are the ones a RFC3164 parser assumes by default (year 0 and UTC).
+func ToRFC3164(m *rfc5424.SyslogMessage) (*rfc3164.SyslogMessage, error) {
+	if m == nil || m.Priority == nil || !common.ValidPriority(*m.Priority) {
+		return nil, ErrPriority
+	}
+
+	var losses Losses
+	out := &rfc3164.SyslogMessage{}
+	out.ComputeFromPriority(*m.Priority)
+
+	if m.Timestamp != nil {
+		t := m.Timestamp.Truncate(time.Second)
+		if !t.Equal(*m.Timestamp) {
+			losses = append(losses, Loss{"timestamp", "truncated to seconds"})
+		}
+		if t.Year() != 0 {
+			losses = append(losses, Loss{"timestamp", "year dropped"})
+		}
+		if _, offset := t.Zone(); offset != 0 {
+			losses = append(losses, Loss{"timestamp", "timezone offset dropped"})
+		}
+		out.Timestamp = &t
+	}
+	out.Hostname = copyString(m.Hostname)
+	if m.MsgID != nil {
+		losses = append(losses, Loss{"msgid", "dropped"})
+	}
+	if m.StructuredData != nil && len(*m.StructuredData) > 0 {
+		losses = append(losses, Loss{"structured data", "dropped"})
+	}
+
+	tag := ""
+	if m.Appname != nil {
+		tag = *m.Appname
+		if i := strings.IndexFunc(tag, func(r rune) bool { return r == ':' || r == '[' }); i >= 0 {
+			tag = tag[:i]
+			losses = append(losses, Loss{"appname", "cut before its first character not allowed in a TAG"})
+		}
+		if len(tag) > maxTag {
+			tag = tag[:maxTag]
+			losses = append(losses, Loss{"appname", fmt.Sprintf("truncated to %d characters", maxTag)})
+		}
+	}
+	content := ""
+	if m.ProcID != nil {
+		if tag == "" {
+			losses = append(losses, Loss{"procid", "dropped since there is no TAG"})
+		} else {
+			content = *m.ProcID
+		}
+	}
+	message := ""
+	if m.Message != nil {
+		message = *m.Message
+	}
+
+	msg := message
+	if tag != "" {
+		msg = tag
+		if content != "" {
+			msg += "[" + content + "]"
+		}
+		msg += ": " + message
+	}
+	if msg == "" {
+		return out, losses.err()
+	}
+
+	out.SetMsg(msg)
+	// The MSG is split back following the RFC3164 heuristics, so check the parts have not been mixed up
+	if value(out.Tag) != tag || value(out.Content) != content || value(out.Message) != message {
+		losses = append(losses, Loss{"message", fmt.Sprintf("only available as a whole MSG since it is split back differently (%q)", msg)})
+	}
+
+	return out, losses.err()
+}
+
+// fit returns a copy of the input value truncated to limit characters,
+// or nil when it contains characters other than printable US-ASCII ones.
+func fit(field string, s *string, limit int, losses *Losses) *string {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	if len(c) > limit {
+		c = c[:limit]
+		*losses = append(*losses, Loss{field, fmt.Sprintf("truncated to %d characters", limit)})
+	}
+	if !isGraph(c) {
+		*losses = append(*losses, Loss{field, "dropped since it contains characters other than printable US-ASCII ones"})
+		return nil
+	}
+	return &c
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// isGraph tells whether the input only contains printable US-ASCII characters except space.
+func isGraph(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 33 || s[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+// isSdName tells whether the input is a valid SD-NAME (ie., a SD-ID or a PARAM-NAME).
+func isSdName(s string) bool {
+	if len(s) == 0 || len(s) > 32 || !isGraph(s) {
+		return false
+	}
+	return !strings.ContainsAny(s, `="]`)
+}
@@ -0,0 +1,242 @@
+package convert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func parse3164(t *testing.T, input string) *rfc3164.SyslogMessage {
+	t.Helper()
+	m, err := rfc3164.NewMachine(rfc3164.WithYear(rfc3164.Year{YYYY: 2019})).Parse([]byte(input))
+	assert.Nil(t, err)
+	return m.(*rfc3164.SyslogMessage)
+}
+
+func TestToRFC5424(t *testing.T) {
+	cases := []struct {
+		input  string
+		output string
+		losses Losses
+	}{
+		{
+			"<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8",
+			"<34>1 2019-10-11T22:14:15Z mymachine su 123 - - 'su root' failed for lonvick on /dev/pts/8",
+			nil,
+		},
+		{
+			"<13>Dec  2 16:31:03 host [no tag] here",
+			"<13>1 2019-12-02T16:31:03Z host - - - - [no tag] here",
+			nil,
+		},
+		{
+			"<13>Dec  2 16:31:03.123456789 host kernel: Started",
+			"<13>1 2019-12-02T16:31:03.123456Z host kernel - - - Started",
+			Losses{{"timestamp", "truncated to microseconds"}},
+		},
+		{
+			"<13>Dec  2 16:31:03 host app[worker 2]: Started",
+			"<13>1 2019-12-02T16:31:03Z host app - - - Started",
+			Losses{{"procid", "dropped since it contains characters other than printable US-ASCII ones"}},
+		},
+		{
+			"<13>Dec  2 16:31:03 host app[" + strings.Repeat("x", 130) + "]: Started",
+			"<13>1 2019-12-02T16:31:03Z host app " + strings.Repeat("x", 128) + " - - Started",
+			Losses{{"procid", "truncated to 128 characters"}},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc.input, 50), func(t *testing.T) {
+			t.Parallel()
+
+			out, err := ToRFC5424(parse3164(t, tc.input))
+			if tc.losses == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, tc.losses, err)
+			}
+			assert.True(t, out.Valid())
+			str, _ := out.String()
+			assert.Equal(t, tc.output, str)
+		})
+	}
+}
+
+func TestToRFC5424WithKeyValues(t *testing.T) {
+	m := parse3164(t, `<13>Dec  2 16:31:03 host app[1]: login user=joe ip=10.0.0.1 msg="a \"quoted\" value" user=bob`)
+	out, err := ToRFC5424(m, WithKeyValues("kv@32473"))
+	assert.Equal(t, Losses{{"message", `value "joe" of key "user" not promoted since the key repeats`}}, err)
+	str, _ := out.String()
+	assert.Equal(t, `<13>1 2019-12-02T16:31:03Z host app 1 - [kv@32473 ip="10.0.0.1" msg="a \"quoted\" value" user="bob"] login user=joe ip=10.0.0.1 msg="a \"quoted\" value" user=bob`, str)
+
+	// Invalid PARAM-NAMEs
+	m = parse3164(t, `<13>Dec  2 16:31:03 host app: a]b=1 c=2`)
+	out, err = ToRFC5424(m, WithKeyValues("kv@32473"))
+	assert.Equal(t, Losses{{"message", `key "a]b" not promoted since it is not a valid PARAM-NAME`}}, err)
+	assert.Equal(t, map[string]map[string]string{"kv@32473": {"c": "2"}}, *out.StructuredData)
+
+	// No pairs
+	m = parse3164(t, `<13>Dec  2 16:31:03 host app: no pairs here`)
+	out, err = ToRFC5424(m, WithKeyValues("kv@32473"))
+	assert.Nil(t, err)
+	assert.Nil(t, out.StructuredData)
+
+	// Invalid SD-ID
+	m = parse3164(t, `<13>Dec  2 16:31:03 host app: c=2`)
+	out, err = ToRFC5424(m, WithKeyValues("kv 1"))
+	assert.Equal(t, Losses{{"message", `key=value pairs not promoted since "kv 1" is not a valid SD-ID`}}, err)
+	assert.Nil(t, out.StructuredData)
+
+	// Invalid SD-ID, no pairs
+	m = parse3164(t, `<13>Dec  2 16:31:03 host app: no pairs here`)
+	out, err = ToRFC5424(m, WithKeyValues("kv 1"))
+	assert.Nil(t, err)
+	assert.Nil(t, out.StructuredData)
+}
+
+func TestToRFC3164(t *testing.T) {
+	cases := []struct {
+		input   string
+		msg     *string
+		tag     *string
+		content *string
+		message *string
+		losses  Losses
+	}{
+		{
+			"<34>1 2019-10-11T22:14:15Z mymachine su 123 - - 'su root' failed",
+			syslogtesting.StringAddress("su[123]: 'su root' failed"),
+			syslogtesting.StringAddress("su"),
+			syslogtesting.StringAddress("123"),
+			syslogtesting.StringAddress("'su root' failed"),
+			Losses{{"timestamp", "year dropped"}},
+		},
+		{
+			"<34>1 2019-10-11T22:14:15Z mymachine su - - - 'su root' failed",
+			syslogtesting.StringAddress("su: 'su root' failed"),
+			syslogtesting.StringAddress("su"),
+			nil,
+			syslogtesting.StringAddress("'su root' failed"),
+			Losses{{"timestamp", "year dropped"}},
+		},
+		{
+			"<34>1 2019-10-11T22:14:15Z mymachine - - - -",
+			nil,
+			nil,
+			nil,
+			nil,
+			Losses{{"timestamp", "year dropped"}},
+		},
+		{
+			"<34>1 0000-10-11T22:14:15-07:00 mymachine su - - - 'su root' failed",
+			syslogtesting.StringAddress("su: 'su root' failed"),
+			syslogtesting.StringAddress("su"),
+			nil,
+			syslogtesting.StringAddress("'su root' failed"),
+			Losses{{"timestamp", "timezone offset dropped"}},
+		},
+		{
+			"<34>1 - mymachine su - - - 'su root' failed",
+			syslogtesting.StringAddress("su: 'su root' failed"),
+			syslogtesting.StringAddress("su"),
+			nil,
+			syslogtesting.StringAddress("'su root' failed"),
+			nil,
+		},
+		{
+			"<34>1 2019-10-11T22:14:15.003Z mymachine su - ID47 [ex@32473 iut=\"3\"] 'su root' failed",
+			syslogtesting.StringAddress("su: 'su root' failed"),
+			syslogtesting.StringAddress("su"),
+			nil,
+			syslogtesting.StringAddress("'su root' failed"),
+			Losses{{"timestamp", "truncated to seconds"}, {"timestamp", "year dropped"}, {"msgid", "dropped"}, {"structured data", "dropped"}},
+		},
+		{
+			"<34>1 - - " + strings.Repeat("a", 40) + " - - - hello",
+			syslogtesting.StringAddress(strings.Repeat("a", 32) + ": hello"),
+			syslogtesting.StringAddress(strings.Repeat("a", 32)),
+			nil,
+			syslogtesting.StringAddress("hello"),
+			Losses{{"appname", "truncated to 32 characters"}},
+		},
+		{
+			"<34>1 - - app:x - - - hello",
+			syslogtesting.StringAddress("app: hello"),
+			syslogtesting.StringAddress("app"),
+			nil,
+			syslogtesting.StringAddress("hello"),
+			Losses{{"appname", "cut before its first character not allowed in a TAG"}},
+		},
+		{
+			"<34>1 - - - 123 - - hello",
+			syslogtesting.StringAddress("hello"),
+			nil,
+			nil,
+			syslogtesting.StringAddress("hello"),
+			Losses{{"procid", "dropped since there is no TAG"}},
+		},
+		{
+			"<34>1 - - - - - - kernel: hello",
+			syslogtesting.StringAddress("kernel: hello"),
+			syslogtesting.StringAddress("kernel"),
+			nil,
+			syslogtesting.StringAddress("hello"),
+			Losses{{"message", `only available as a whole MSG since it is split back differently ("kernel: hello")`}},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc.input, 50), func(t *testing.T) {
+			t.Parallel()
+
+			m, err := rfc5424.NewMachine().Parse([]byte(tc.input))
+			assert.Nil(t, err)
+
+			out, err := ToRFC3164(m.(*rfc5424.SyslogMessage))
+			if tc.losses == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, tc.losses, err)
+			}
+			assert.True(t, out.Valid())
+			assert.Equal(t, *m.(*rfc5424.SyslogMessage).Priority, *out.Priority)
+			assert.Equal(t, tc.msg, out.Msg)
+			assert.Equal(t, tc.tag, out.Tag)
+			assert.Equal(t, tc.tag, out.Appname)
+			assert.Equal(t, tc.content, out.Content)
+			assert.Equal(t, tc.message, out.Message)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	m := parse3164(t, "<13>Dec  2 16:31:03 host sshd[1234]: Accepted publickey")
+
+	m5424, err := ToRFC5424(m)
+	assert.Nil(t, err)
+	m3164, err := ToRFC3164(m5424)
+	// The year comes from the parser, not from the RFC3164 timestamp
+	assert.Equal(t, Losses{{"timestamp", "year dropped"}}, err)
+	assert.Equal(t, m, m3164)
+}
+
+func TestInvalidPriority(t *testing.T) {
+	_, err := ToRFC5424(nil)
+	assert.Equal(t, ErrPriority, err)
+	_, err = ToRFC5424(&rfc3164.SyslogMessage{})
+	assert.Equal(t, ErrPriority, err)
+	_, err = ToRFC3164(&rfc5424.SyslogMessage{})
+	assert.Equal(t, ErrPriority, err)
+}
+
+func TestLossesError(t *testing.T) {
+	err := Losses{{"msgid", "dropped"}, {"structured data", "dropped"}}
+	assert.EqualError(t, err, "lossy conversion: msgid dropped; structured data dropped")
+}
@@ -0,0 +1,85 @@
+package syslog
+
+import (
+	"strings"
+)
+
+// Decoder decodes the MSG part of a syslog message (eg., a CEF event), returning the message to use in its place.
+//
+// Its error is not a parsing one: the machines and the listeners wrapping it only report it when the parsing succeeded.
+type Decoder func(a Accessor) (Message, error)
+
+// Decoded is embedded by the messages whose MSG part has been decoded.
+//
+// It exposes the parts of the original message, while Original returns the original message itself.
+type Decoded struct {
+	Accessor
+}
+
+// Original returns the message as parsed by the wrapped machine (eg., a *rfc5424.SyslogMessage or a *rfc3164.SyslogMessage).
+func (d *Decoded) Original() Message {
+	return d.Accessor
+}
+
+// Payload returns the MSG part of the message, to decode it. It returns false when the message has none.
+//
+// Since parsing RFC3164 messages splits their TAG off the MSG part, the payloads starting with a prefix
+// that looks like a TAG followed by a colon (eg., "CEF:") lose it: when the TAG is the one of the prefix, the prefix is put back.
+// An empty prefix returns the MSG part as is.
+func Payload(m Message, prefix string) (string, bool) {
+	a, ok := m.(Accessor)
+	if !ok || a.GetMessage() == nil {
+		return "", false
+	}
+
+	body := *a.GetMessage()
+	tag := strings.TrimSuffix(prefix, ":")
+	if appname := a.GetAppname(); a.Format() == "rfc3164" && tag != "" && appname != nil && *appname == tag && !strings.HasPrefix(body, prefix) {
+		body = prefix + body
+	}
+
+	return body, true
+}
+
+// decode passes the message through the decoder, unless it is nil or it does not implement Accessor.
+func (d Decoder) decode(m Message) (Message, error) {
+	a, ok := m.(Accessor)
+	if !ok {
+		return m, nil
+	}
+
+	return d(a)
+}
+
+type decodingMachine struct {
+	Machine
+	decoder Decoder
+}
+
+// NewDecodingMachine wraps the machine (eg., a RFC5424 or a RFC3164 one) so that it also decodes the MSG part of the messages.
+func NewDecodingMachine(m Machine, d Decoder) Machine {
+	return &decodingMachine{Machine: m, decoder: d}
+}
+
+// Parse parses the input syslog message with the wrapped machine, then it decodes its MSG part.
+func (m *decodingMachine) Parse(input []byte) (Message, error) {
+	msg, err := m.Machine.Parse(input)
+	out, derr := m.decoder.decode(msg)
+	if err == nil {
+		err = derr
+	}
+
+	return out, err
+}
+
+// NewDecodingListener wraps the listener (eg., of the octetcounting or the nontransparent parsers) so that it receives decoded messages.
+func NewDecodingListener(l ParserListener, d Decoder) ParserListener {
+	return func(res *Result) {
+		out, err := d.decode(res.Message)
+		if res.Error == nil {
+			res.Error = err
+		}
+		res.Message = out
+		l(res)
+	}
+}
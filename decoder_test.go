@@ -0,0 +1,112 @@
+package syslog_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/nontransparent"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+)
+
+var errUpper = errors.New("upper case message")
+
+type upperMessage struct {
+	syslog.Decoded
+	Upper bool
+}
+
+// decodeUpper tells whether the MSG part is in upper case, failing when it is.
+func decodeUpper(a syslog.Accessor) (syslog.Message, error) {
+	out := &upperMessage{Decoded: syslog.Decoded{Accessor: a}}
+	if msg := a.GetMessage(); msg != nil && strings.ToUpper(*msg) == *msg {
+		out.Upper = true
+		return out, errUpper
+	}
+
+	return out, nil
+}
+
+func TestDecodingMachine(t *testing.T) {
+	p := syslog.NewDecodingMachine(rfc5424.NewMachine(), decodeUpper)
+
+	m, err := p.Parse([]byte(`<13>1 - host app - - - hello`))
+	assert.Nil(t, err)
+	assert.False(t, m.(*upperMessage).Upper)
+	assert.Equal(t, "host", *m.(*upperMessage).GetHostname())
+	assert.IsType(t, &rfc5424.SyslogMessage{}, m.(*upperMessage).Original())
+	assert.Equal(t, uint16(1), m.(*upperMessage).Original().(*rfc5424.SyslogMessage).Version)
+
+	// Decoding errors
+	m, err = p.Parse([]byte(`<13>1 - host app - - - HELLO`))
+	assert.Equal(t, errUpper, err)
+	assert.True(t, m.(*upperMessage).Upper)
+
+	// Syslog errors prevail and nil messages are not decoded
+	m, err = p.Parse([]byte(`<13>`))
+	assert.Error(t, err)
+	assert.NotEqual(t, errUpper, err)
+	assert.Nil(t, m)
+
+	// Partial messages are decoded
+	p = syslog.NewDecodingMachine(rfc3164.NewMachine(rfc3164.WithBestEffort()), decodeUpper)
+	m, err = p.Parse([]byte("<13>Dec  2 16:31:03 host HELLO\x01"))
+	assert.Error(t, err)
+	assert.NotEqual(t, errUpper, err)
+	assert.IsType(t, &rfc3164.SyslogMessage{}, m.(*upperMessage).Original())
+
+	// Options reach the wrapped machine
+	assert.True(t, p.HasBestEffort())
+}
+
+func TestDecodingListener(t *testing.T) {
+	input := "<13>1 - host app - - - hello\n<13>1 - host app - - - HELLO\n<13>\n"
+	var results []*syslog.Result
+	p := nontransparent.NewParser(syslog.WithListener(syslog.NewDecodingListener(func(res *syslog.Result) {
+		results = append(results, res)
+	}, decodeUpper)))
+	p.Parse(strings.NewReader(input))
+
+	assert.Len(t, results, 3)
+	assert.Nil(t, results[0].Error)
+	assert.False(t, results[0].Message.(*upperMessage).Upper)
+	assert.Equal(t, errUpper, results[1].Error)
+	assert.True(t, results[1].Message.(*upperMessage).Upper)
+	assert.Error(t, results[2].Error)
+	assert.Nil(t, results[2].Message)
+}
+
+func TestPayload(t *testing.T) {
+	cases := []struct {
+		input   string
+		machine syslog.Machine
+		prefix  string
+		payload string
+	}{
+		{`<13>1 - host app - - - CEF:0|V|P`, rfc5424.NewMachine(), "CEF:", "CEF:0|V|P"},
+		// Only the TAG of RFC3164 messages is a prefix of their payload
+		{`<13>1 - host CEF - - - 0|V|P`, rfc5424.NewMachine(), "CEF:", "0|V|P"},
+		{`<13>Dec  2 16:31:03 host CEF:0|V|P`, rfc3164.NewMachine(), "CEF:", "CEF:0|V|P"},
+		{`<13>Dec  2 16:31:03 host @cee: {"a":1}`, rfc3164.NewMachine(), "@cee:", `@cee:{"a":1}`},
+		{`<13>Dec  2 16:31:03 host app: CEF:0|V|P`, rfc3164.NewMachine(), "CEF:", "CEF:0|V|P"},
+		{`<13>Dec  2 16:31:03 host app: a=b`, rfc3164.NewMachine(), "", "a=b"},
+	}
+
+	for _, tc := range cases {
+		m, err := tc.machine.Parse([]byte(tc.input))
+		assert.Nil(t, err)
+		payload, ok := syslog.Payload(m, tc.prefix)
+		assert.True(t, ok, tc.input)
+		assert.Equal(t, tc.payload, payload, tc.input)
+	}
+
+	m, err := rfc5424.NewMachine().Parse([]byte(`<13>1 - host app - - -`))
+	assert.Nil(t, err)
+	_, ok := syslog.Payload(m, "CEF:")
+	assert.False(t, ok)
+	_, ok = syslog.Payload(nil, "CEF:")
+	assert.False(t, ok)
+}
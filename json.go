@@ -0,0 +1,92 @@
+package syslog
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3/common"
+)
+
+// BaseJSON is the JSON representation of the fields in common among syslog messages.
+//
+// Absent fields are omitted.
+// The facility, the severity, and their keywords (eg., "local4" and "notice") are derived from the priority,
+// so they are ignored when unmarshalling a document containing the priority.
+// The timestamp is in RFC3339 format, with nanoseconds when present.
+type BaseJSON struct {
+	Priority        *uint8     `json:"priority,omitempty"`
+	Facility        *uint8     `json:"facility,omitempty"`
+	FacilityKeyword *string    `json:"facility_keyword,omitempty"`
+	Severity        *uint8     `json:"severity,omitempty"`
+	SeverityKeyword *string    `json:"severity_keyword,omitempty"`
+	Timestamp       *time.Time `json:"timestamp,omitempty"`
+	Hostname        *string    `json:"hostname,omitempty"`
+	Appname         *string    `json:"appname,omitempty"`
+	ProcID          *string    `json:"procid,omitempty"`
+	MsgID           *string    `json:"msgid,omitempty"`
+	Message         *string    `json:"message,omitempty"`
+}
+
+// JSON returns the JSON representation of the receiving message.
+func (m *Base) JSON() BaseJSON {
+	return BaseJSON{
+		Priority:        m.Priority,
+		Facility:        m.Facility,
+		FacilityKeyword: m.FacilityLevel(),
+		Severity:        m.Severity,
+		SeverityKeyword: m.SeverityShortLevel(),
+		Timestamp:       m.Timestamp,
+		Hostname:        m.Hostname,
+		Appname:         m.Appname,
+		ProcID:          m.ProcID,
+		MsgID:           m.MsgID,
+		Message:         m.Message,
+	}
+}
+
+// FromJSON sets the receiving message from its JSON representation.
+//
+// When the priority is missing it is computed from the facility and the severity, if both present.
+func (m *Base) FromJSON(j BaseJSON) error {
+	*m = Base{}
+	switch {
+	case j.Priority != nil:
+		if !common.ValidPriority(*j.Priority) {
+			return fmt.Errorf("invalid priority %d", *j.Priority)
+		}
+		m.ComputeFromPriority(*j.Priority)
+	case j.Facility != nil && j.Severity != nil:
+		if *j.Facility > 23 || *j.Severity > 7 {
+			return fmt.Errorf("invalid facility %d or severity %d", *j.Facility, *j.Severity)
+		}
+		m.ComputeFromPriority(*j.Facility*8 + *j.Severity)
+	}
+	m.Timestamp = j.Timestamp
+	m.Hostname = j.Hostname
+	m.Appname = j.Appname
+	m.ProcID = j.ProcID
+	m.MsgID = j.MsgID
+	m.Message = j.Message
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// See BaseJSON for the schema.
+func (m *Base) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.JSON())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// See BaseJSON for the schema.
+func (m *Base) UnmarshalJSON(data []byte) error {
+	var j BaseJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	return m.FromJSON(j)
+}
@@ -0,0 +1,61 @@
+package kmsg
+
+import (
+	"strings"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+func output(out interface{}) {
+	spew.Config.DisableCapacities = true
+	spew.Config.DisablePointerAddresses = true
+	spew.Dump(out)
+}
+
+func Example() {
+	i := []byte("6,1234,5678901,-;usb 1-1: new high-speed USB device number 2 using xhci_hcd\n SUBSYSTEM=usb\n")
+	p := NewParser(WithBootTime(time.Date(2023, 11, 14, 13, 30, 8, 0, time.UTC)))
+	m, _ := p.Parse(i)
+	output(m)
+	// Output:
+	// (*kmsg.SyslogMessage)({
+	//  Base: (syslog.Base) {
+	//   Facility: (*uint8)(0),
+	//   Severity: (*uint8)(6),
+	//   Priority: (*uint8)(6),
+	//   Timestamp: (*time.Time)(2023-11-14 13:30:13.678901 +0000 UTC),
+	//   Hostname: (*string)(<nil>),
+	//   Appname: (*string)(<nil>),
+	//   ProcID: (*string)(<nil>),
+	//   MsgID: (*string)(<nil>),
+	//   Message: (*string)((len=58) "usb 1-1: new high-speed USB device number 2 using xhci_hcd")
+	//  },
+	//  Sequence: (*uint64)(1234),
+	//  Monotonic: (*time.Duration)(5.678901s),
+	//  Flags: (*string)((len=1) "-"),
+	//  Dict: (map[string]string) (len=1) {
+	//   (string) (len=9) "SUBSYSTEM": (string) (len=3) "usb"
+	//  }
+	// })
+}
+
+func ExampleReader() {
+	r := NewReader(strings.NewReader("6,1,10,-;first\n6,4,20,-;second\n"))
+	for {
+		m, err := r.Next()
+		if err != nil {
+			output(err)
+			if _, ok := err.(*GapError); ok {
+				continue
+			}
+			break
+		}
+		output(*m.(*SyslogMessage).Message)
+	}
+	// Output:
+	// (string) (len=5) "first"
+	// (*kmsg.GapError)(missed 2 records (sequence numbers from 2 to 3))
+	// (string) (len=6) "second"
+	// (*errors.errorString)(EOF)
+}
@@ -0,0 +1,67 @@
+package kmsg
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+type jsonMessage struct {
+	Format string `json:"format"`
+	syslog.BaseJSON
+	Sequence  *uint64           `json:"sequence,omitempty"`
+	Monotonic *int64            `json:"monotonic_usec,omitempty"`
+	Flags     *string           `json:"flags,omitempty"`
+	Dict      map[string]string `json:"dict,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The resulting object contains the "format" key (always "kmsg"), the keys of syslog.BaseJSON,
+// the "sequence" key, the "monotonic_usec" key with the time elapsed since boot in microseconds, the "flags" key,
+// and the "dict" key with the KEY=value pairs of the continuation lines.
+func (m *SyslogMessage) MarshalJSON() ([]byte, error) {
+	j := jsonMessage{
+		Format:   Format,
+		BaseJSON: m.Base.JSON(),
+		Sequence: m.Sequence,
+		Flags:    m.Flags,
+		Dict:     m.Dict,
+	}
+	if m.Monotonic != nil {
+		usec := int64(*m.Monotonic / time.Microsecond)
+		j.Monotonic = &usec
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It accepts the objects produced by MarshalJSON.
+// The "format" key can be omitted, otherwise it must be "kmsg".
+func (m *SyslogMessage) UnmarshalJSON(data []byte) error {
+	var j jsonMessage
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Format != "" && j.Format != Format {
+		return fmt.Errorf("expecting format %q, got %q", Format, j.Format)
+	}
+
+	*m = SyslogMessage{}
+	if err := m.Base.FromJSON(j.BaseJSON); err != nil {
+		return err
+	}
+	m.Sequence = j.Sequence
+	if j.Monotonic != nil {
+		monotonic := time.Duration(*j.Monotonic) * time.Microsecond
+		m.Monotonic = &monotonic
+	}
+	m.Flags = j.Flags
+	m.Dict = j.Dict
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package kmsg
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	m, err := NewMachine().Parse([]byte("6,5,1843211,-;usb 1-1: new high-speed USB device number 2 using xhci_hcd\n SUBSYSTEM=usb\n DEVICE=c189:1"))
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"format": "kmsg",
+		"priority": 6,
+		"facility": 0,
+		"facility_keyword": "kern",
+		"severity": 6,
+		"severity_keyword": "info",
+		"message": "usb 1-1: new high-speed USB device number 2 using xhci_hcd",
+		"sequence": 5,
+		"monotonic_usec": 1843211,
+		"flags": "-",
+		"dict": {"SUBSYSTEM": "usb", "DEVICE": "c189:1"}
+	}`, string(data))
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	cases := []string{
+		"6,0,0,-;Linux version 5.15.0-91-generic",
+		"6,5,1843211,-;usb 1-1: new high-speed USB device number 2 using xhci_hcd\n SUBSYSTEM=usb\n DEVICE=c189:1",
+		"3,7,3016554,c;EXT4-fs (sda1): tab\\x09in message",
+		"30,9,4000000,+,caller=T1;continued",
+	}
+	boot := time.Date(2023, time.November, 14, 13, 30, 8, 0, time.UTC)
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := NewMachine(WithBootTime(boot)).Parse([]byte(tc))
+			assert.Nil(t, err)
+
+			data, err := json.Marshal(m)
+			assert.Nil(t, err)
+
+			got := &SyslogMessage{}
+			assert.Nil(t, json.Unmarshal(data, got))
+			assert.Equal(t, m, got)
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	got := &SyslogMessage{}
+	assert.EqualError(t, json.Unmarshal([]byte(`{"format": "rfc5424", "priority": 1}`), got), `expecting format "kmsg", got "rfc5424"`)
+}
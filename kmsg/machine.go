@@ -0,0 +1,172 @@
+// Package kmsg parses the records of the Linux kernel ring buffer, as read from /dev/kmsg.
+//
+// A record is made of a prefix, of a message, and of optional continuation lines:
+//
+//	6,1234,5678901,-;usb 1-1: new high-speed USB device number 2 using xhci_hcd
+//	 SUBSYSTEM=usb
+//	 DEVICE=c189:1
+//
+// The prefix contains the priority, the sequence number, the time elapsed since boot (in microseconds), and the flags, separated by commas.
+// Unprintable characters of the message and of the continuation lines are escaped as \xHH.
+package kmsg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/common"
+)
+
+var (
+	errPrefix    = "expecting a prefix made of priority, sequence number, timestamp, and flags separated by commas and followed by a semicolon [col %d]"
+	errPrival    = "expecting a priority value in the range 1-191 or equal to 0 [col %d]"
+	errSequence  = "expecting a sequence number [col %d]"
+	errTimestamp = "expecting a timestamp in microseconds [col %d]"
+	errFlags     = "expecting flags (-, c, or +) [col %d]"
+	errDict      = "expecting a continuation line made of a space and a KEY=value pair [col %d]"
+)
+
+type machine struct {
+	bestEffort bool
+	bootTime   *time.Time
+}
+
+// NewMachine creates a new parser of kernel ring buffer records.
+func NewMachine(options ...syslog.MachineOption) syslog.Machine {
+	m := &machine{}
+
+	for _, opt := range options {
+		opt(m)
+	}
+
+	return m
+}
+
+// NewParser creates a syslog.Machine that parses kernel ring buffer records.
+//
+// Since the machine is stateless, it is safe for concurrent use.
+func NewParser(options ...syslog.MachineOption) syslog.Machine {
+	return NewMachine(options...)
+}
+
+// WithBestEffort enables best effort mode.
+func (m *machine) WithBestEffort() {
+	m.bestEffort = true
+}
+
+// HasBestEffort tells whether the receiving machine has best effort mode on or off.
+func (m *machine) HasBestEffort() bool {
+	return m.bestEffort
+}
+
+// WithBootTime sets the boot time the monotonic timestamps of the records are relative to.
+func (m *machine) WithBootTime(t time.Time) {
+	m.bootTime = &t
+}
+
+// Parse parses the input record.
+//
+// It returns a message and an error when parsing fails only in best effort mode,
+// and the message contains the parts parsed before the error.
+// A trailing new line is ignored.
+func (m *machine) Parse(input []byte) (syslog.Message, error) {
+	out, err := m.parse(strings.TrimSuffix(string(input), "\n"))
+	if err != nil {
+		if m.bestEffort && out != nil {
+			return out, err
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (m *machine) parse(s string) (*SyslogMessage, error) {
+	end := strings.IndexByte(s, ';')
+	if nl := strings.IndexByte(s, '\n'); end < 0 || (nl >= 0 && nl < end) {
+		return nil, fmt.Errorf(errPrefix, 0)
+	}
+	// Fields following the flags are reserved for future extensions
+	fields := strings.Split(s[:end], ",")
+	if len(fields) < 4 {
+		return nil, fmt.Errorf(errPrefix, end)
+	}
+
+	col := 0
+	prio, err := strconv.ParseUint(fields[0], 10, 8)
+	if err != nil || !common.ValidPriority(uint8(prio)) {
+		return nil, fmt.Errorf(errPrival, col)
+	}
+	out := &SyslogMessage{}
+	out.ComputeFromPriority(uint8(prio))
+
+	col += len(fields[0]) + 1
+	seq, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return out, fmt.Errorf(errSequence, col)
+	}
+	out.Sequence = &seq
+
+	col += len(fields[1]) + 1
+	usec, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil || usec < 0 {
+		return out, fmt.Errorf(errTimestamp, col)
+	}
+	monotonic := time.Duration(usec) * time.Microsecond
+	out.Monotonic = &monotonic
+	if m.bootTime != nil {
+		t := m.bootTime.Add(monotonic)
+		out.Timestamp = &t
+	}
+
+	col += len(fields[2]) + 1
+	flags := fields[3]
+	if flags != "-" && flags != "c" && flags != "+" {
+		return out, fmt.Errorf(errFlags, col)
+	}
+	out.Flags = &flags
+
+	lines := strings.Split(s[end+1:], "\n")
+	if msg := unescape(lines[0]); msg != "" {
+		out.Message = &msg
+	}
+
+	col = end + 1 + len(lines[0]) + 1
+	for _, line := range lines[1:] {
+		eq := strings.IndexByte(line, '=')
+		if len(line) < 2 || line[0] != ' ' || eq < 2 {
+			return out, fmt.Errorf(errDict, col)
+		}
+		if out.Dict == nil {
+			out.Dict = map[string]string{}
+		}
+		out.Dict[line[1:eq]] = unescape(line[eq+1:])
+		col += len(line) + 1
+	}
+
+	return out, nil
+}
+
+// unescape replaces the \xHH escape sequences with the characters they stand for.
+func unescape(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && s[i+1] == 'x' {
+			if c, err := strconv.ParseUint(s[i+2:i+4], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package kmsg
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func message(prio uint8, seq uint64, usec int64, flags, msg string, dict map[string]string) *SyslogMessage {
+	m := &SyslogMessage{Sequence: &seq, Flags: &flags, Dict: dict}
+	m.ComputeFromPriority(prio)
+	monotonic := time.Duration(usec) * time.Microsecond
+	m.Monotonic = &monotonic
+	if msg != "" {
+		m.Message = &msg
+	}
+
+	return m
+}
+
+func TestMachineParse(t *testing.T) {
+	cases := []struct {
+		input   string
+		output  syslog.Message
+		err     error
+		partial syslog.Message
+	}{
+		{
+			"6,1234,5678901,-;hello world\n",
+			message(6, 1234, 5678901, "-", "hello world", nil),
+			nil,
+			nil,
+		},
+		{
+			"6,5,1843211,-,caller=T42;usb 1-1: new device\n SUBSYSTEM=usb\n DEVICE=c189:1\n",
+			message(6, 5, 1843211, "-", "usb 1-1: new device", map[string]string{"SUBSYSTEM": "usb", "DEVICE": "c189:1"}),
+			nil,
+			nil,
+		},
+		{
+			`30,7,12,c;tab\x09and backslash\x5c`,
+			message(30, 7, 12, "c", "tab\tand backslash\\", nil),
+			nil,
+			nil,
+		},
+		{
+			"14,8,12,+;",
+			message(14, 8, 12, "+", "", nil),
+			nil,
+			nil,
+		},
+		{
+			"hello",
+			nil,
+			fmt.Errorf(errPrefix, 0),
+			nil,
+		},
+		{
+			"6,1;hello",
+			nil,
+			fmt.Errorf(errPrefix, 3),
+			nil,
+		},
+		{
+			"192,1,2,-;hello",
+			nil,
+			fmt.Errorf(errPrival, 0),
+			nil,
+		},
+		{
+			"6,x,2,-;hello",
+			nil,
+			fmt.Errorf(errSequence, 2),
+			&SyslogMessage{Base: message(6, 0, 0, "-", "", nil).Base},
+		},
+		{
+			"6,1,-2,-;hello",
+			nil,
+			fmt.Errorf(errTimestamp, 4),
+			&SyslogMessage{Base: message(6, 1, 0, "-", "", nil).Base, Sequence: message(6, 1, 0, "-", "", nil).Sequence},
+		},
+		{
+			"6,1,2,x;hello",
+			nil,
+			fmt.Errorf(errFlags, 6),
+			func() *SyslogMessage {
+				m := message(6, 1, 2, "-", "", nil)
+				m.Flags = nil
+				return m
+			}(),
+		},
+		{
+			"6,1,2,-;hello\n A=1\nB=2",
+			nil,
+			fmt.Errorf(errDict, 19),
+			message(6, 1, 2, "-", "hello", map[string]string{"A": "1"}),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc.input, 50), func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := NewMachine().Parse([]byte(tc.input))
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.output, msg)
+
+			partial, perr := NewMachine(WithBestEffort()).Parse([]byte(tc.input))
+			assert.Equal(t, tc.err, perr)
+			if tc.err == nil {
+				assert.Equal(t, tc.output, partial)
+			} else {
+				assert.Equal(t, tc.partial, partial)
+			}
+		})
+	}
+}
+
+func TestMachineBootTime(t *testing.T) {
+	boot := time.Date(2023, 11, 14, 13, 30, 8, 0, time.UTC)
+	msg, err := NewMachine(WithBootTime(boot)).Parse([]byte("6,1,1500000,-;hello"))
+	assert.Nil(t, err)
+	assert.Equal(t, boot.Add(1500*time.Millisecond), *msg.(*SyslogMessage).Timestamp)
+
+	msg, err = NewMachine().Parse([]byte("6,1,1500000,-;hello"))
+	assert.Nil(t, err)
+	assert.Nil(t, msg.(*SyslogMessage).Timestamp)
+}
+
+func TestAccessor(t *testing.T) {
+	msg, err := NewParser().Parse([]byte("6,1,2,-;hello"))
+	assert.Nil(t, err)
+	a := msg.(syslog.Accessor)
+	assert.Equal(t, "kmsg", a.Format())
+	assert.Equal(t, "hello", *a.GetMessage())
+	assert.Equal(t, uint8(6), *a.GetPriority())
+	assert.Nil(t, a.GetStructuredData())
+}
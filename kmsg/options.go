@@ -0,0 +1,25 @@
+package kmsg
+
+import (
+	"time"
+
+	syslog "github.com/influxdata/go-syslog/v3"
+)
+
+// WithBestEffort enables the best effort mode.
+func WithBestEffort() syslog.MachineOption {
+	return func(m syslog.Machine) syslog.Machine {
+		m.WithBestEffort()
+		return m
+	}
+}
+
+// WithBootTime sets the boot time of the system, so that the records get a Timestamp (ie., the boot time plus their monotonic time).
+//
+// Note that the monotonic clock does not advance while the system is suspended, so timestamps can lag behind the wall clock.
+func WithBootTime(t time.Time) syslog.MachineOption {
+	return func(m syslog.Machine) syslog.Machine {
+		m.(*machine).WithBootTime(t)
+		return m
+	}
+}
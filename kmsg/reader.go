@@ -0,0 +1,139 @@
+package kmsg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+// maxRecordLength is the size of the buffers of the reads, since /dev/kmsg fails reads with smaller buffers than the next record.
+const maxRecordLength = 8192
+
+// DevKmsg is the path of the device exposing the kernel ring buffer.
+const DevKmsg = "/dev/kmsg"
+
+// GapError reports the records that have been overwritten in the ring buffer before they have been read.
+type GapError struct {
+	// From is the sequence number of the first missed record.
+	From uint64
+	// To is the sequence number of the first record read after the gap.
+	To uint64
+}
+
+// Error returns the textual representation of the gap.
+func (e *GapError) Error() string {
+	return fmt.Sprintf("missed %d records (sequence numbers from %d to %d)", e.To-e.From, e.From, e.To-1)
+}
+
+// Reader reads the records of the kernel ring buffer from /dev/kmsg, or from files containing them (eg., recorded with cat).
+//
+// The device returns a record at every read, failing with EPIPE when the next record has been overwritten:
+// the reader goes on with the oldest available record, reporting the records in between with a *GapError.
+// Gaps in the sequence numbers of recorded files are reported the same way.
+type Reader struct {
+	r       io.Reader
+	machine syslog.Machine
+	buf     []byte
+	pending []byte
+	aligned bool // Whether the pending data ends with a record
+	err     error
+	next    *uint64
+	queued  *result
+}
+
+type result struct {
+	msg syslog.Message
+	err error
+}
+
+// NewReader creates a reader parsing the records with a machine created with the given options.
+func NewReader(r io.Reader, options ...syslog.MachineOption) *Reader {
+	return &Reader{
+		r:       r,
+		machine: NewMachine(options...),
+		buf:     make([]byte, maxRecordLength),
+	}
+}
+
+// Next returns the next record.
+//
+// It returns a nil message and a *GapError before the first record following a gap, and io.EOF at the end of the input.
+// Parsing errors are returned together with the partial message in best effort mode.
+func (r *Reader) Next() (syslog.Message, error) {
+	if q := r.queued; q != nil {
+		r.queued = nil
+		return q.msg, q.err
+	}
+
+	for {
+		if rec, ok := r.record(); ok {
+			return r.parse(rec)
+		}
+		if r.err != nil {
+			if len(r.pending) > 0 {
+				rec := r.pending
+				r.pending = nil
+				return r.parse(rec)
+			}
+			return nil, r.err
+		}
+
+		n, err := r.r.Read(r.buf)
+		r.pending = append(r.pending, r.buf[:n]...)
+		// Short reads end with a record, as the ones from the device
+		r.aligned = n > 0 && n < len(r.buf) && r.buf[n-1] == '\n'
+		if err != nil && !errors.Is(err, syscall.EPIPE) {
+			r.err = err
+		}
+	}
+}
+
+// record returns the next complete record among the pending data.
+func (r *Reader) record() ([]byte, bool) {
+	i := bytes.IndexByte(r.pending, '\n')
+	if i < 0 {
+		return nil, false
+	}
+
+	// Continuation lines start with a space
+	j := i + 1
+	for j < len(r.pending) && r.pending[j] == ' ' {
+		k := bytes.IndexByte(r.pending[j:], '\n')
+		if k < 0 {
+			return nil, false
+		}
+		j += k + 1
+	}
+	if j == len(r.pending) && !r.aligned {
+		return nil, false
+	}
+
+	rec := r.pending[:j]
+	r.pending = r.pending[j:]
+
+	return rec, true
+}
+
+func (r *Reader) parse(rec []byte) (syslog.Message, error) {
+	msg, err := r.machine.Parse(rec)
+	m, ok := msg.(*SyslogMessage)
+	if !ok || m.Sequence == nil {
+		return msg, err
+	}
+
+	seq := *m.Sequence
+	expected := r.next
+	r.next = new(uint64)
+	*r.next = seq + 1
+	// Sequence numbers restart when the device is reopened or seeked, so only jumps ahead are gaps
+	if expected != nil && seq > *expected {
+		r.queued = &result{msg, err}
+		return nil, &GapError{From: *expected, To: seq}
+	}
+
+	return msg, err
+}
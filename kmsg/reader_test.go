@@ -0,0 +1,106 @@
+package kmsg
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReaderFixture(t *testing.T) {
+	f, err := os.Open("testdata/kmsg.txt")
+	assert.Nil(t, err)
+	defer f.Close()
+
+	r := NewReader(f)
+	var sequences []uint64
+	var gaps []*GapError
+	for {
+		msg, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if gap, ok := err.(*GapError); ok {
+			assert.Nil(t, msg)
+			gaps = append(gaps, gap)
+			continue
+		}
+		assert.Nil(t, err)
+		sequences = append(sequences, *msg.(*SyslogMessage).Sequence)
+		if *msg.(*SyslogMessage).Sequence == 5 {
+			assert.Equal(t, map[string]string{"SUBSYSTEM": "usb", "DEVICE": "c189:1"}, msg.(*SyslogMessage).Dict)
+		}
+	}
+
+	assert.Equal(t, []uint64{0, 1, 2, 5, 6, 7, 8}, sequences)
+	assert.Equal(t, []*GapError{{From: 3, To: 5}}, gaps)
+	assert.Equal(t, "missed 2 records (sequence numbers from 3 to 4)", gaps[0].Error())
+}
+
+// device mimics /dev/kmsg, returning a record (or an error) at every read.
+type device struct {
+	reads []interface{}
+}
+
+func (d *device) Read(p []byte) (int, error) {
+	if len(d.reads) == 0 {
+		return 0, io.EOF
+	}
+	next := d.reads[0]
+	d.reads = d.reads[1:]
+	if err, ok := next.(error); ok {
+		return 0, err
+	}
+	if len(p) < maxRecordLength {
+		return 0, syscall.EINVAL
+	}
+
+	return copy(p, next.(string)), nil
+}
+
+func TestReaderDevice(t *testing.T) {
+	r := NewReader(&device{reads: []interface{}{
+		"6,10,1,-;first\n SUBSYSTEM=usb\n",
+		"6,11,2,-;second\n",
+		&os.PathError{Op: "read", Path: DevKmsg, Err: syscall.EPIPE},
+		"6,20,3,-;after the gap\n",
+	}})
+
+	msg, err := r.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "first", *msg.(*SyslogMessage).Message)
+	assert.Equal(t, map[string]string{"SUBSYSTEM": "usb"}, msg.(*SyslogMessage).Dict)
+
+	msg, err = r.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "second", *msg.(*SyslogMessage).Message)
+
+	msg, err = r.Next()
+	assert.Equal(t, &GapError{From: 12, To: 20}, err)
+	assert.Nil(t, msg)
+
+	msg, err = r.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "after the gap", *msg.(*SyslogMessage).Message)
+
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReaderErrors(t *testing.T) {
+	r := NewReader(&device{reads: []interface{}{
+		"garbage\n",
+		"6,1,2,-;ok",
+	}}, WithBestEffort())
+
+	msg, err := r.Next()
+	assert.Error(t, err)
+	assert.Nil(t, msg)
+
+	// The last record can lack the new line
+	msg, err = r.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", *msg.(*SyslogMessage).Message)
+}
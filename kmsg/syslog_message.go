@@ -0,0 +1,36 @@
+package kmsg
+
+import (
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+// Format is the name of the format of the Linux kernel ring buffer records.
+const Format = "kmsg"
+
+// SyslogMessage represents a record of the Linux kernel ring buffer, as read from /dev/kmsg.
+//
+// Its priority has the same semantics of the syslog one (eg., facility 0 is kern).
+// The Timestamp is only present when parsing with the WithBootTime option, since records only carry the time elapsed since boot.
+type SyslogMessage struct {
+	syslog.Base
+	// Sequence is the sequence number of the record, which increases by one for every record.
+	Sequence *uint64
+	// Monotonic is the time elapsed since boot, with microseconds precision.
+	Monotonic *time.Duration
+	// Flags is "-" for normal records, "c" for the first fragment of a continued line, and "+" for the following ones.
+	Flags *string
+	// Dict contains the KEY=value pairs of the continuation lines (eg., SUBSYSTEM and DEVICE).
+	Dict map[string]string
+}
+
+// Format returns "kmsg".
+func (m *SyslogMessage) Format() string {
+	return Format
+}
+
+// GetStructuredData returns nil, since kernel records do not have structured data.
+func (m *SyslogMessage) GetStructuredData() *map[string]map[string]string {
+	return nil
+}
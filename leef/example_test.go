@@ -0,0 +1,31 @@
+package leef
+
+import (
+	"github.com/davecgh/go-spew/spew"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+)
+
+func output(out interface{}) {
+	spew.Config.DisableCapacities = true
+	spew.Config.DisablePointerAddresses = true
+	spew.Dump(out)
+}
+
+func Example() {
+	i := []byte("<13>Jan 18 11:07:53 host LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=192.0.2.0")
+	p := NewMachine(rfc3164.NewParser())
+	m, _ := p.Parse(i)
+	output(m.(*Message).Event)
+	// Output:
+	// (*leef.Event)({
+	//  Version: (string) (len=3) "2.0",
+	//  Vendor: (string) (len=7) "Lancope",
+	//  Product: (string) (len=12) "StealthWatch",
+	//  ProductVersion: (string) (len=3) "1.0",
+	//  EventID: (string) (len=2) "41",
+	//  Delimiter: (uint8) 94,
+	//  Attributes: (map[string]string) (len=1) {
+	//   (string) (len=3) "src": (string) (len=9) "192.0.2.0"
+	//  }
+	// })
+}
@@ -0,0 +1,245 @@
+// Package leef decodes IBM QRadar Log Event Extended Format (LEEF) events carried in the MSG part of syslog messages.
+//
+// It supports both the versions of the format:
+//
+//	LEEF:1.0|Vendor|Product|Version|EventID|key=value<TAB>key=value
+//	LEEF:2.0|Vendor|Product|Version|EventID|DelimiterCharacter|key=value<DelimiterCharacter>key=value
+//
+// The delimiter character of LEEF 2.0 can be a single character or its hexadecimal code (eg., ^, x5E, or 0x5E).
+// When it is omitted or empty the attributes are separated by tabs, as in LEEF 1.0.
+package leef
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	prefix  = "LEEF:"
+	utf8BOM = "\xEF\xBB\xBF"
+)
+
+var (
+	// ErrNotLEEF is returned for inputs not starting with the LEEF prefix.
+	ErrNotLEEF = errors.New("expecting a LEEF event (starting with LEEF:)")
+	// ErrHeader is returned for malformed LEEF headers.
+	ErrHeader = errors.New("expecting a LEEF header with version (1.0 or 2.0), vendor, product, version, and event id separated by pipes")
+	// ErrDelimiter is returned for LEEF 2.0 headers with an invalid delimiter character.
+	ErrDelimiter = errors.New("expecting a LEEF delimiter made of a character or of its hexadecimal code")
+	// ErrAttributes is returned for malformed LEEF attributes.
+	ErrAttributes = errors.New("expecting LEEF key=value attributes separated by the delimiter character")
+)
+
+// Event represents a LEEF event.
+type Event struct {
+	Version        string
+	Vendor         string
+	Product        string
+	ProductVersion string
+	EventID        string
+	Delimiter      byte
+	Attributes     map[string]string
+}
+
+// Parse decodes a LEEF event.
+//
+// Leading spaces and UTF-8 byte order mark are ignored.
+func Parse(input []byte) (*Event, error) {
+	s := strings.TrimLeft(strings.TrimPrefix(string(input), utf8BOM), " ")
+	if !strings.HasPrefix(s, prefix) {
+		return nil, ErrNotLEEF
+	}
+
+	fields := strings.SplitN(s[len(prefix):], "|", 6)
+	if len(fields) < 6 || (fields[0] != "1.0" && fields[0] != "2.0") {
+		return nil, ErrHeader
+	}
+	e := &Event{
+		Version:        fields[0],
+		Vendor:         fields[1],
+		Product:        fields[2],
+		ProductVersion: fields[3],
+		EventID:        fields[4],
+		Delimiter:      '\t',
+	}
+
+	attributes := fields[5]
+	if e.Version == "2.0" {
+		// The delimiter field is optional
+		if i := strings.IndexByte(attributes, '|'); i >= 0 && !strings.Contains(attributes[:i], "=") {
+			// An empty one means the tab, too
+			if i > 0 {
+				d, err := parseDelimiter(attributes[:i])
+				if err != nil {
+					return nil, err
+				}
+				e.Delimiter = d
+			}
+			attributes = attributes[i+1:]
+		}
+	}
+
+	var err error
+	if e.Attributes, err = parseAttributes(attributes, e.Delimiter); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// SourceAddress returns the value of the src attribute, nil when absent or invalid.
+func (e *Event) SourceAddress() net.IP {
+	return net.ParseIP(e.Attributes["src"])
+}
+
+// DestinationAddress returns the value of the dst attribute, nil when absent or invalid.
+func (e *Event) DestinationAddress() net.IP {
+	return net.ParseIP(e.Attributes["dst"])
+}
+
+// SourcePort returns the value of the srcPort attribute.
+func (e *Event) SourcePort() (int, bool) {
+	return e.number("srcPort", 0, 65535)
+}
+
+// DestinationPort returns the value of the dstPort attribute.
+func (e *Event) DestinationPort() (int, bool) {
+	return e.number("dstPort", 0, 65535)
+}
+
+// Severity returns the value of the sev attribute, from 1 to 10.
+func (e *Event) Severity() (int, bool) {
+	return e.number("sev", 1, 10)
+}
+
+// DevTime returns the value of the devTime attribute, parsed according to the devTimeFormat attribute.
+//
+// The format defaults to "MMM dd yyyy HH:mm:ss", while the "Milliseconds" format stands for milliseconds since epoch.
+// Only the most common letters of Java date format patterns are supported (eg., yyyy, MMM, dd, HH, mm, ss, SSS, a, z, and Z).
+func (e *Event) DevTime() (time.Time, bool) {
+	v, ok := e.Attributes["devTime"]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	format, ok := e.Attributes["devTimeFormat"]
+	if !ok {
+		format = "MMM dd yyyy HH:mm:ss"
+	}
+	if format == "Milliseconds" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC(), true
+	}
+
+	t, err := time.Parse(layout(format), v)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+func (e *Event) number(key string, min, max int) (int, bool) {
+	n, err := strconv.Atoi(e.Attributes[key])
+	if err != nil || n < min || n > max {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// layout converts a Java date format pattern into a Go layout.
+func layout(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); {
+		c := format[i]
+		// Quoted literals
+		if c == '\'' {
+			end := strings.IndexByte(format[i+1:], '\'')
+			if end < 0 {
+				b.WriteString(format[i+1:])
+				break
+			}
+			b.WriteString(format[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+
+		n := 1
+		for i+n < len(format) && format[i+n] == c {
+			n++
+		}
+		i += n
+		if l, ok := javaLayouts[string(c)+strconv.Itoa(n)]; ok {
+			b.WriteString(l)
+			continue
+		}
+		b.WriteString(strings.Repeat(string(c), n))
+	}
+
+	return b.String()
+}
+
+// javaLayouts maps the letters of Java date format patterns, with their number, to Go layouts.
+var javaLayouts = map[string]string{
+	"y4": "2006",
+	"y2": "06",
+	"M3": "Jan",
+	"M2": "01",
+	"M1": "1",
+	"d2": "02",
+	"d1": "2",
+	"H2": "15",
+	"h2": "03",
+	"h1": "3",
+	"m2": "04",
+	"s2": "05",
+	"S3": "000",
+	"a1": "PM",
+	"z1": "MST",
+	"z3": "MST",
+	"Z1": "-0700",
+	"X3": "Z07:00",
+}
+
+func parseDelimiter(s string) (byte, error) {
+	switch {
+	case len(s) == 1:
+		return s[0], nil
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		s = s[2:]
+	case strings.HasPrefix(s, "x") || strings.HasPrefix(s, "X"):
+		s = s[1:]
+	default:
+		return 0, ErrDelimiter
+	}
+
+	n, err := strconv.ParseUint(s, 16, 8)
+	if err != nil || n == 0 {
+		return 0, ErrDelimiter
+	}
+
+	return byte(n), nil
+}
+
+func parseAttributes(s string, delimiter byte) (map[string]string, error) {
+	out := map[string]string{}
+	for _, pair := range strings.Split(strings.TrimRight(s, "\r\n"), string([]byte{delimiter})) {
+		if pair == "" {
+			continue
+		}
+		i := strings.IndexByte(pair, '=')
+		if i <= 0 {
+			return nil, ErrAttributes
+		}
+		out[strings.TrimSpace(pair[:i])] = pair[i+1:]
+	}
+
+	return out, nil
+}
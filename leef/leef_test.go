@@ -0,0 +1,132 @@
+package leef
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+var attributes = map[string]string{
+	"src":     "192.0.2.0",
+	"dst":     "172.50.123.1",
+	"sev":     "5",
+	"cat":     "anomaly",
+	"srcPort": "81",
+	"dstPort": "21",
+	"usrName": "joe.black",
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input string
+		event *Event
+		err   error
+	}{
+		// Published examples
+		{
+			"LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tcat=anomaly\tsrcPort=81\tdstPort=21\tusrName=joe.black",
+			&Event{"1.0", "Microsoft", "MSExchange", "4.0 SP1", "15345", '\t', attributes},
+			nil,
+		},
+		{
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=192.0.2.0^dst=172.50.123.1^sev=5^cat=anomaly^srcPort=81^dstPort=21^usrName=joe.black",
+			&Event{"2.0", "Lancope", "StealthWatch", "1.0", "41", '^', attributes},
+			nil,
+		},
+		{
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|0x5E|src=192.0.2.0^dst=172.50.123.1^sev=5^cat=anomaly^srcPort=81^dstPort=21^usrName=joe.black",
+			&Event{"2.0", "Lancope", "StealthWatch", "1.0", "41", '^', attributes},
+			nil,
+		},
+		{
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|x5E|src=192.0.2.0^dst=172.50.123.1^sev=5^cat=anomaly^srcPort=81^dstPort=21^usrName=joe.black",
+			&Event{"2.0", "Lancope", "StealthWatch", "1.0", "41", '^', attributes},
+			nil,
+		},
+		// Without the delimiter character
+		{
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tcat=anomaly\tsrcPort=81\tdstPort=21\tusrName=joe.black",
+			&Event{"2.0", "Lancope", "StealthWatch", "1.0", "41", '\t', attributes},
+			nil,
+		},
+		{
+			"LEEF:2.0|V|P|1|e||a=b\tc=d",
+			&Event{"2.0", "V", "P", "1", "e", '\t', map[string]string{"a": "b", "c": "d"}},
+			nil,
+		},
+		{
+			"\xEF\xBB\xBFLEEF:2.0|V|P|1|e|;|url=https://example.com/?a=b|c;msg=\n",
+			&Event{"2.0", "V", "P", "1", "e", ';', map[string]string{"url": "https://example.com/?a=b|c", "msg": ""}},
+			nil,
+		},
+		{
+			"LEEF:1.0|V|P|1|e|",
+			&Event{"1.0", "V", "P", "1", "e", '\t', map[string]string{}},
+			nil,
+		},
+		{"CEF:0|V|P|1|s|n|3|", nil, ErrNotLEEF},
+		{"LEEF:3.0|V|P|1|e|", nil, ErrHeader},
+		{"LEEF:1.0|V|P|1|e", nil, ErrHeader},
+		{"LEEF:2.0|V|P|1|e|xZZ|a=b", nil, ErrDelimiter},
+		{"LEEF:2.0|V|P|1|e|abc|a=b", nil, ErrDelimiter},
+		{"LEEF:1.0|V|P|1|e|a=b\tnovalue", nil, ErrAttributes},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc.input, 50), func(t *testing.T) {
+			t.Parallel()
+
+			e, err := Parse([]byte(tc.input))
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.event, e)
+		})
+	}
+}
+
+func TestTypedAttributes(t *testing.T) {
+	e := &Event{Attributes: attributes}
+	assert.Equal(t, net.ParseIP("192.0.2.0"), e.SourceAddress())
+	assert.Equal(t, net.ParseIP("172.50.123.1"), e.DestinationAddress())
+	port, ok := e.SourcePort()
+	assert.True(t, ok)
+	assert.Equal(t, 81, port)
+	port, ok = e.DestinationPort()
+	assert.True(t, ok)
+	assert.Equal(t, 21, port)
+	sev, ok := e.Severity()
+	assert.True(t, ok)
+	assert.Equal(t, 5, sev)
+
+	e = &Event{Attributes: map[string]string{"sev": "0", "srcPort": "x"}}
+	_, ok = e.Severity()
+	assert.False(t, ok)
+	_, ok = e.SourcePort()
+	assert.False(t, ok)
+	assert.Nil(t, e.SourceAddress())
+}
+
+func TestDevTime(t *testing.T) {
+	cases := []struct {
+		attributes map[string]string
+		time       time.Time
+		ok         bool
+	}{
+		{map[string]string{"devTime": "Oct 11 2017 22:14:15"}, time.Date(2017, 10, 11, 22, 14, 15, 0, time.UTC), true},
+		{map[string]string{"devTime": "1507760055003", "devTimeFormat": "Milliseconds"}, time.Date(2017, 10, 11, 22, 14, 15, 3000000, time.UTC), true},
+		{map[string]string{"devTime": "2017-10-11T22:14:15.003+0000", "devTimeFormat": "yyyy-MM-dd'T'HH:mm:ss.SSSZ"}, time.Date(2017, 10, 11, 22, 14, 15, 3000000, time.FixedZone("", 0)), true},
+		{map[string]string{"devTime": "11/10/17 10:14:15 PM", "devTimeFormat": "dd/MM/yy hh:mm:ss a"}, time.Date(2017, 10, 11, 22, 14, 15, 0, time.UTC), true},
+		{map[string]string{"devTime": "yesterday"}, time.Time{}, false},
+		{map[string]string{"devTime": "x", "devTimeFormat": "Milliseconds"}, time.Time{}, false},
+		{map[string]string{}, time.Time{}, false},
+	}
+
+	for _, tc := range cases {
+		got, ok := (&Event{Attributes: tc.attributes}).DevTime()
+		assert.Equal(t, tc.ok, ok)
+		assert.True(t, tc.time.Equal(got), "expected %s, got %s", tc.time, got)
+	}
+}
@@ -0,0 +1,47 @@
+package leef
+
+import (
+	"github.com/influxdata/go-syslog/v3"
+)
+
+// Message represents a syslog message whose MSG part has been decoded.
+//
+// Event is nil when the MSG part does not contain a LEEF event.
+type Message struct {
+	syslog.Decoded
+	Event *Event
+}
+
+// FromMessage decodes the LEEF event in the MSG part of the syslog message (see syslog.Payload).
+func FromMessage(m syslog.Message) (*Event, error) {
+	body, ok := syslog.Payload(m, prefix)
+	if !ok {
+		return nil, ErrNotLEEF
+	}
+
+	return Parse([]byte(body))
+}
+
+// NewMachine wraps the machine so that it also decodes the LEEF events (see syslog.NewDecodingMachine).
+//
+// The messages it returns are *Message instances.
+// The errors of the decoding are only returned for MSG parts starting with the LEEF prefix.
+func NewMachine(m syslog.Machine) syslog.Machine {
+	return syslog.NewDecodingMachine(m, decode)
+}
+
+// Listener wraps the listener so that it receives decoded messages (see syslog.NewDecodingListener).
+func Listener(l syslog.ParserListener) syslog.ParserListener {
+	return syslog.NewDecodingListener(l, decode)
+}
+
+func decode(a syslog.Accessor) (syslog.Message, error) {
+	out := &Message{Decoded: syslog.Decoded{Accessor: a}}
+	e, err := FromMessage(a)
+	if err == ErrNotLEEF {
+		return out, nil
+	}
+	out.Event = e
+
+	return out, err
+}
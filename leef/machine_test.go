@@ -0,0 +1,55 @@
+package leef
+
+import (
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMessage(t *testing.T) {
+	m, err := rfc5424.NewParser().Parse([]byte(`<134>1 - host - - - - LEEF:2.0|V|P|1|e|^|src=10.0.0.1`))
+	assert.Nil(t, err)
+	e, err := FromMessage(m)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", e.Attributes["src"])
+
+	// RFC3164 messages with the LEEF tag
+	m, err = rfc3164.NewParser().Parse([]byte(`<134>Feb 14 19:04:54 host LEEF:2.0|V|P|1|e|^|src=10.0.0.1`))
+	assert.Nil(t, err)
+	e, err = FromMessage(m)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", e.Attributes["src"])
+
+	m, err = rfc5424.NewParser().Parse([]byte(`<134>1 - host - - - -`))
+	assert.Nil(t, err)
+	_, err = FromMessage(m)
+	assert.Equal(t, ErrNotLEEF, err)
+}
+
+func TestMachine(t *testing.T) {
+	p := NewMachine(rfc5424.NewParser())
+
+	m, err := p.Parse([]byte(`<134>1 - host - - - - LEEF:2.0|V|P|1|e|^|src=10.0.0.1`))
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", m.(*Message).Event.Attributes["src"])
+	assert.Equal(t, "host", *m.(*Message).GetHostname())
+	assert.IsType(t, &rfc5424.SyslogMessage{}, m.(*Message).Original())
+
+	// Tab-delimited LEEF 1.0 events, inside RFC3164 messages
+	m, err = NewMachine(rfc3164.NewParser()).Parse([]byte("<13>Dec  2 16:31:03 host LEEF:1.0|V|P|1|E|src=1\tdst=2"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"src": "1", "dst": "2"}, m.(*Message).Event.Attributes)
+	assert.Equal(t, "host", *m.(*Message).GetHostname())
+
+	// Not a LEEF event
+	m, err = p.Parse([]byte(`<134>1 - host - - - - hello`))
+	assert.Nil(t, err)
+	assert.Nil(t, m.(*Message).Event)
+
+	// Malformed LEEF event
+	m, err = p.Parse([]byte(`<134>1 - host - - - - LEEF:1.0|V`))
+	assert.Equal(t, ErrHeader, err)
+	assert.Nil(t, m.(*Message).Event)
+}
@@ -0,0 +1,34 @@
+package logfmt
+
+import (
+	"github.com/davecgh/go-spew/spew"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+)
+
+func output(out interface{}) {
+	spew.Config.DisableCapacities = true
+	spew.Config.DisablePointerAddresses = true
+	spew.Dump(out)
+}
+
+func Example() {
+	i := []byte(`<13>1 2003-10-11T22:14:15.003Z host app - - - level=info msg="user logged in" user=joe`)
+	p := NewMachine(rfc5424.NewParser())
+	m, _ := p.Parse(i)
+	output(m.(*Message).Pairs)
+	// Output:
+	// (logfmt.Pairs) (len=3) {
+	//  (logfmt.Pair) {
+	//   Key: (string) (len=5) "level",
+	//   Value: (string) (len=4) "info"
+	//  },
+	//  (logfmt.Pair) {
+	//   Key: (string) (len=3) "msg",
+	//   Value: (string) (len=14) "user logged in"
+	//  },
+	//  (logfmt.Pair) {
+	//   Key: (string) (len=4) "user",
+	//   Value: (string) (len=3) "joe"
+	//  }
+	// }
+}
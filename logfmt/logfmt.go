@@ -0,0 +1,146 @@
+// Package logfmt extracts the key=value pairs that applications write in the MSG part of syslog messages.
+//
+// It tokenizes the logfmt style pairs (eg., as written by logrus and go-kit):
+//
+//	level=info msg="user logged in" user=joe latency=12ms
+//
+// Values can be double quoted, with Go escape sequences, and words not containing an equal sign are free text, thus ignored:
+//
+//	User logged in: user=joe ip=10.0.0.1
+package logfmt
+
+import (
+	"strconv"
+	"strings"
+)
+
+const utf8BOM = "\xEF\xBB\xBF"
+
+// Pair represents a key=value pair.
+type Pair struct {
+	Key   string
+	Value string
+}
+
+// Pairs represents the key=value pairs in their order of appearance, duplicate keys included.
+type Pairs []Pair
+
+// Get returns the value of the last pair with the given key.
+func (p Pairs) Get(key string) (string, bool) {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].Key == key {
+			return p[i].Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Map returns the pairs by key, the last one winning for duplicate keys.
+func (p Pairs) Map() map[string]string {
+	out := make(map[string]string, len(p))
+	for _, pair := range p {
+		out[pair.Key] = pair.Value
+	}
+
+	return out
+}
+
+// Parse extracts the key=value pairs from the input.
+//
+// It never fails: the text that is not made of pairs is skipped,
+// while quoted values lacking the closing quote extend to the end of the input.
+func Parse(input []byte) Pairs {
+	s := strings.TrimPrefix(string(input), utf8BOM)
+
+	var out Pairs
+	for i := 0; i < len(s); {
+		if isSpace(s[i]) {
+			i++
+			continue
+		}
+
+		// Quoted free text
+		if s[i] == '"' {
+			_, n := quoted(s[i:])
+			i = skipWord(s, i+n)
+			continue
+		}
+
+		start := i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '"' {
+			i++
+		}
+		if i == len(s) || s[i] != '=' || i == start {
+			i = skipWord(s, i)
+			continue
+		}
+		key := s[start:i]
+		i++
+
+		var value string
+		if i < len(s) && s[i] == '"' {
+			var n int
+			value, n = quoted(s[i:])
+			i = skipWord(s, i+n)
+		} else {
+			start = i
+			for i < len(s) && !isSpace(s[i]) {
+				i++
+			}
+			value = s[start:i]
+		}
+		out = append(out, Pair{Key: key, Value: value})
+	}
+
+	return out
+}
+
+// quoted returns the unquoted value of the double quoted string the input starts with, and its length.
+func quoted(s string) (string, int) {
+	end := 1
+	escapes := false
+	for end < len(s) && s[end] != '"' {
+		if s[end] == '\\' {
+			escapes = true
+			end++
+		}
+		end++
+	}
+	if end >= len(s) {
+		return unescape(s[1:]), len(s)
+	}
+	if !escapes {
+		return s[1:end], end + 1
+	}
+	if v, err := strconv.Unquote(s[:end+1]); err == nil {
+		return v, end + 1
+	}
+
+	return unescape(s[1:end]), end + 1
+}
+
+// unescape removes the backslashes escaping double quotes and backslashes.
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\\') {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+func skipWord(s string, i int) int {
+	for i < len(s) && !isSpace(s[i]) {
+		i++
+	}
+
+	return i
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
@@ -0,0 +1,66 @@
+package logfmt
+
+import (
+	"testing"
+
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input string
+		pairs Pairs
+	}{
+		{
+			`level=info msg="user logged in" user=joe latency=12ms`,
+			Pairs{{"level", "info"}, {"msg", "user logged in"}, {"user", "joe"}, {"latency", "12ms"}},
+		},
+		{
+			`User logged in: user=joe ip=10.0.0.1`,
+			Pairs{{"user", "joe"}, {"ip", "10.0.0.1"}},
+		},
+		{
+			`a=1 a=2 empty= url=http://example.com/?x=y`,
+			Pairs{{"a", "1"}, {"a", "2"}, {"empty", ""}, {"url", "http://example.com/?x=y"}},
+		},
+		{
+			`msg="say \"hi\"\n\tbye" path="C:\\tmp" emoji="\u263a" bad="\q"`,
+			Pairs{{"msg", "say \"hi\"\n\tbye"}, {"path", `C:\tmp`}, {"emoji", "☺"}, {"bad", `\q`}},
+		},
+		{
+			`"quoted a=b text" k=v =x "unterminated a=b`,
+			Pairs{{"k", "v"}},
+		},
+		{
+			`k="unterminated \"value`,
+			Pairs{{"k", `unterminated "value`}},
+		},
+		{
+			"\xEF\xBB\xBFk=\"v\"trailing\tnext=1\r\n",
+			Pairs{{"k", "v"}, {"next", "1"}},
+		},
+		{`no pairs here`, nil},
+		{``, nil},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc.input, 50), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.pairs, Parse([]byte(tc.input)))
+		})
+	}
+}
+
+func TestPairs(t *testing.T) {
+	p := Parse([]byte(`a=1 b=2 a=3`))
+
+	v, ok := p.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "3", v)
+	_, ok = p.Get("c")
+	assert.False(t, ok)
+	assert.Equal(t, map[string]string{"a": "3", "b": "2"}, p.Map())
+}
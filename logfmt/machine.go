@@ -0,0 +1,39 @@
+package logfmt
+
+import (
+	"github.com/influxdata/go-syslog/v3"
+)
+
+// Message represents a syslog message whose MSG part has been tokenized.
+//
+// Pairs is nil when the MSG part does not contain any key=value pair.
+type Message struct {
+	syslog.Decoded
+	Pairs Pairs
+}
+
+// FromMessage extracts the key=value pairs from the MSG part of the syslog message.
+func FromMessage(m syslog.Message) Pairs {
+	body, ok := syslog.Payload(m, "")
+	if !ok {
+		return nil
+	}
+
+	return Parse([]byte(body))
+}
+
+// NewMachine wraps the machine so that it also extracts the key=value pairs (see syslog.NewDecodingMachine).
+//
+// The messages it returns are *Message instances.
+func NewMachine(m syslog.Machine) syslog.Machine {
+	return syslog.NewDecodingMachine(m, decode)
+}
+
+// Listener wraps the listener so that it receives tokenized messages (see syslog.NewDecodingListener).
+func Listener(l syslog.ParserListener) syslog.ParserListener {
+	return syslog.NewDecodingListener(l, decode)
+}
+
+func decode(a syslog.Accessor) (syslog.Message, error) {
+	return &Message{Decoded: syslog.Decoded{Accessor: a}, Pairs: FromMessage(a)}, nil
+}
@@ -0,0 +1,29 @@
+package logfmt
+
+import (
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMessage(t *testing.T) {
+	m, err := rfc3164.NewParser().Parse([]byte(`<13>Jan  1 00:00:00 host app[1]: login user=joe`))
+	assert.Nil(t, err)
+	assert.Equal(t, Pairs{{"user", "joe"}}, FromMessage(m))
+
+	m, err = rfc5424.NewParser().Parse([]byte(`<13>1 - host app - - -`))
+	assert.Nil(t, err)
+	assert.Nil(t, FromMessage(m))
+}
+
+func TestMachine(t *testing.T) {
+	p := NewMachine(rfc5424.NewParser())
+
+	m, err := p.Parse([]byte(`<13>1 - host app - - - login user=joe`))
+	assert.Nil(t, err)
+	assert.Equal(t, Pairs{{"user", "joe"}}, m.(*Message).Pairs)
+	assert.Equal(t, "host", *m.(*Message).GetHostname())
+	assert.IsType(t, &rfc5424.SyslogMessage{}, m.(*Message).Original())
+}
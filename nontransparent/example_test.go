@@ -35,7 +35,8 @@ func Example_withoutTrailerAtEnd() {
 	//     Message: (*string)((len=3) "mex")
 	//    },
 	//    Version: (uint16) 1,
-	//    StructuredData: (*map[string]map[string]string)(<nil>)
+	//    StructuredData: (*map[string]map[string]string)(<nil>),
+	//    Raw: (*rfc5424.Raw)(<nil>)
 	//   }),
 	//   Error: (*ragel.ReadingError)(unexpected EOF)
 	//  }
@@ -66,7 +67,8 @@ func Example_bestEffortOnLastOne() {
 	//     Message: (*string)((len=1) "-")
 	//    },
 	//    Version: (uint16) 1,
-	//    StructuredData: (*map[string]map[string]string)(<nil>)
+	//    StructuredData: (*map[string]map[string]string)(<nil>),
+	//    Raw: (*rfc5424.Raw)(<nil>)
 	//   }),
 	//   Error: (error) <nil>
 	//  },
@@ -84,7 +86,8 @@ func Example_bestEffortOnLastOne() {
 	//     Message: (*string)(<nil>)
 	//    },
 	//    Version: (uint16) 1,
-	//    StructuredData: (*map[string]map[string]string)(<nil>)
+	//    StructuredData: (*map[string]map[string]string)(<nil>),
+	//    Raw: (*rfc5424.Raw)(<nil>)
 	//   }),
 	//   Error: (*errors.errorString)(parsing error [col 4])
 	//  }
@@ -146,7 +149,8 @@ func Example_intoChannelWithLF() {
 	//    Message: (*string)((len=3) "A\nB")
 	//   },
 	//   Version: (uint16) 1,
-	//   StructuredData: (*map[string]map[string]string)(<nil>)
+	//   StructuredData: (*map[string]map[string]string)(<nil>),
+	//   Raw: (*rfc5424.Raw)(<nil>)
 	//  }),
 	//  Error: (error) <nil>
 	// })
@@ -164,7 +168,8 @@ func Example_intoChannelWithLF() {
 	//    Message: (*string)(<nil>)
 	//   },
 	//   Version: (uint16) 1,
-	//   StructuredData: (*map[string]map[string]string)(<nil>)
+	//   StructuredData: (*map[string]map[string]string)(<nil>),
+	//   Raw: (*rfc5424.Raw)(<nil>)
 	//  }),
 	//  Error: (*errors.errorString)(parsing error [col 6])
 	// })
@@ -182,11 +187,11 @@ func Example_intoChannelWithLF() {
 	//    Message: (*string)((len=7) "A\nB\nC\nD")
 	//   },
 	//   Version: (uint16) 1,
-	//   StructuredData: (*map[string]map[string]string)(<nil>)
+	//   StructuredData: (*map[string]map[string]string)(<nil>),
+	//   Raw: (*rfc5424.Raw)(<nil>)
 	//  }),
 	//  Error: (error) <nil>
 	// })
-
 }
 
 func Example_intoChannelWithNUL() {
@@ -245,7 +250,8 @@ func Example_intoChannelWithNUL() {
 	//    Message: (*string)((len=3) "A\x00B")
 	//   },
 	//   Version: (uint16) 1,
-	//   StructuredData: (*map[string]map[string]string)(<nil>)
+	//   StructuredData: (*map[string]map[string]string)(<nil>),
+	//   Raw: (*rfc5424.Raw)(<nil>)
 	//  }),
 	//  Error: (error) <nil>
 	// })
@@ -267,7 +273,8 @@ func Example_intoChannelWithNUL() {
 	//    Message: (*string)((len=7) "A\x00B\x00C\x00D")
 	//   },
 	//   Version: (uint16) 1,
-	//   StructuredData: (*map[string]map[string]string)(<nil>)
+	//   StructuredData: (*map[string]map[string]string)(<nil>),
+	//   Raw: (*rfc5424.Raw)(<nil>)
 	//  }),
 	//  Error: (error) <nil>
 	// })
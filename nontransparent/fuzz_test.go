@@ -0,0 +1,43 @@
+//go:build go1.18
+// +build go1.18
+
+package nontransparent
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+func FuzzParse(f *testing.F) {
+	for _, tc := range getTestCases() {
+		for _, t := range []TrailerType{LF, NUL} {
+			input := tc.input
+			if tc.substitute {
+				v, _ := t.Value()
+				input = fmt.Sprintf(tc.input, string(rune(v)))
+			}
+			f.Add([]byte(input), uint8(t))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte, trailer uint8) {
+		tt := TrailerType(trailer % uint8(len(names)))
+		for _, bestEffort := range []bool{false, true} {
+			opts := []syslog.ParserOption{WithTrailer(tt), syslog.WithListener(func(res *syslog.Result) {
+				if res.Message == nil && res.Error == nil {
+					t.Fatal("empty result")
+				}
+				if res.Error == nil && !res.Message.Valid() {
+					t.Fatal("invalid message without errors")
+				}
+			})}
+			if bestEffort {
+				opts = append(opts, syslog.WithBestEffort())
+			}
+			NewParser(opts...).Parse(strings.NewReader(string(input)))
+		}
+	})
+}
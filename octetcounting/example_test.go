@@ -39,7 +39,8 @@ func Example() {
 	//     Message: (*string)(<nil>)
 	//    },
 	//    Version: (uint16) 1,
-	//    StructuredData: (*map[string]map[string]string)(<nil>)
+	//    StructuredData: (*map[string]map[string]string)(<nil>),
+	//    Raw: (*rfc5424.Raw)(<nil>)
 	//   }),
 	//   Error: (error) <nil>
 	//  },
@@ -57,7 +58,8 @@ func Example() {
 	//     Message: (*string)(<nil>)
 	//    },
 	//    Version: (uint16) 1,
-	//    StructuredData: (*map[string]map[string]string)(<nil>)
+	//    StructuredData: (*map[string]map[string]string)(<nil>),
+	//    Raw: (*rfc5424.Raw)(<nil>)
 	//   }),
 	//   Error: (error) <nil>
 	//  },
@@ -75,7 +77,8 @@ func Example() {
 	//     Message: (*string)((len=11) "κόσμε")
 	//    },
 	//    Version: (uint16) 1,
-	//    StructuredData: (*map[string]map[string]string)(<nil>)
+	//    StructuredData: (*map[string]map[string]string)(<nil>),
+	//    Raw: (*rfc5424.Raw)(<nil>)
 	//   }),
 	//   Error: (error) <nil>
 	//  }
@@ -132,7 +135,8 @@ func Example_channel() {
 	//    Message: (*string)(<nil>)
 	//   },
 	//   Version: (uint16) 1,
-	//   StructuredData: (*map[string]map[string]string)(<nil>)
+	//   StructuredData: (*map[string]map[string]string)(<nil>),
+	//   Raw: (*rfc5424.Raw)(<nil>)
 	//  }),
 	//  Error: (error) <nil>
 	// }
@@ -150,7 +154,8 @@ func Example_channel() {
 	//    Message: (*string)(<nil>)
 	//   },
 	//   Version: (uint16) 12,
-	//   StructuredData: (*map[string]map[string]string)(<nil>)
+	//   StructuredData: (*map[string]map[string]string)(<nil>),
+	//   Raw: (*rfc5424.Raw)(<nil>)
 	//  }),
 	//  Error: (*errors.errorString)(expecting a RFC3339MICRO timestamp or a nil value [col 6])
 	// }
@@ -168,7 +173,8 @@ func Example_channel() {
 	//    Message: (*string)(<nil>)
 	//   },
 	//   Version: (uint16) 1,
-	//   StructuredData: (*map[string]map[string]string)(<nil>)
+	//   StructuredData: (*map[string]map[string]string)(<nil>),
+	//   Raw: (*rfc5424.Raw)(<nil>)
 	//  }),
 	//  Error: (*errors.errorString)(parsing error [col 4])
 	// }
@@ -34,6 +34,9 @@ func NewParser(opts ...syslog.ParserOption) syslog.Parser {
 	}
 
 	// Create internal parser depending on options
+	if p.internal != nil {
+		return p
+	}
 	if p.bestEffort {
 		p.internal = rfc5424.NewMachine(rfc5424.WithBestEffort())
 	} else {
@@ -43,6 +46,19 @@ func NewParser(opts ...syslog.ParserOption) syslog.Parser {
 	return p
 }
 
+// WithMachine sets the machine parsing the SYSLOGMSG of the frames (a RFC5424 one by default).
+//
+// The parser only checks the length of the frames, so they can contain messages in any format (eg., RFC3164 ones).
+// The best effort mode of the parser does not affect the given machine.
+func WithMachine(m syslog.Machine) syslog.ParserOption {
+	return func(p syslog.Parser) syslog.Parser {
+		if m != nil {
+			p.(*parser).internal = m
+		}
+		return p
+	}
+}
+
 func (p *parser) WithMaxMessageLength(length int) {
 	p.maxMessageLength = length
 }
@@ -86,7 +102,7 @@ func (p *parser) run() {
 			break
 		}
 
-		if int(p.s.msglen) > p.maxMessageLength {
+		if p.s.msglen > uint64(p.maxMessageLength) {
 			p.emit(&syslog.Result{
 				Error: fmt.Errorf("message too long to parse. was size %d, max length %d", p.s.msglen, p.maxMessageLength),
 			})
@@ -106,7 +122,7 @@ func (p *parser) run() {
 			e := fmt.Errorf(`found %s after "%s", expecting a %s containing %d octets`, tok, tok.lit, SYSLOGMSG, p.s.msglen)
 			// Underflow case
 			if len(tok.lit) < int(p.s.msglen) && p.bestEffort {
-				// Though MSGLEN was not respected, we try to parse the existing SYSLOGMSG as a RFC5424 syslog message
+				// Though MSGLEN was not respected, we try to parse the existing SYSLOGMSG
 				result := p.parse(tok.lit)
 				if result.Error == nil {
 					result.Error = e
@@ -121,7 +137,7 @@ func (p *parser) run() {
 			break
 		}
 
-		// Parse the SYSLOGMSG literal pretending it is a RFC5424 syslog message
+		// Parse the SYSLOGMSG literal with the internal machine
 		result := p.parse(tok.lit)
 		if p.bestEffort || result.Error == nil {
 			p.emit(result)
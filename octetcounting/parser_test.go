@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
 	"github.com/influxdata/go-syslog/v3/rfc5424"
 	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +26,10 @@ func getTimestampError(col int) error {
 	return fmt.Errorf(rfc5424.ErrTimestamp+rfc5424.ColumnPositionTemplate, col)
 }
 
+func getPriorityError(col int) error {
+	return fmt.Errorf(rfc5424.ErrPri+rfc5424.ColumnPositionTemplate, col)
+}
+
 func getParsingError(col int) error {
 	return fmt.Errorf(rfc5424.ErrParse+rfc5424.ColumnPositionTemplate, col)
 }
@@ -595,6 +600,93 @@ func getTestCases() []testCase {
 				},
 			},
 		},
+		{
+			descr: "ko//zero MSGLEN",
+			input: "0 <1>1 - - - - - -",
+			// results w/o best effort
+			results: []syslog.Result{
+				{Error: fmt.Errorf("found %s, expecting a %s", Token{ILLEGAL, []byte("0")}, MSGLEN)},
+			},
+			// results with best effort
+			bestEffortResults: []syslog.Result{
+				{Error: fmt.Errorf("found %s, expecting a %s", Token{ILLEGAL, []byte("0")}, MSGLEN)},
+			},
+		},
+		{
+			descr: "ko//slash in MSGLEN",
+			input: "1/6 <1>1 - - - - - -",
+			// results w/o best effort
+			results: []syslog.Result{
+				{Error: fmt.Errorf("found %s, expecting a %s", Token{ILLEGAL, []byte("/")}, WS)},
+			},
+			// results with best effort
+			bestEffortResults: []syslog.Result{
+				{Error: fmt.Errorf("found %s, expecting a %s", Token{ILLEGAL, []byte("/")}, WS)},
+			},
+		},
+		{
+			descr: "ko//MSGLEN too long",
+			input: "1000000000000000000 <1>1 - - - - - -",
+			// results w/o best effort
+			results: []syslog.Result{
+				{Error: fmt.Errorf("found %s, expecting a %s", Token{ILLEGAL, []byte("1000000000000000000")}, MSGLEN)},
+			},
+			// results with best effort
+			bestEffortResults: []syslog.Result{
+				{Error: fmt.Errorf("found %s, expecting a %s", Token{ILLEGAL, []byte("1000000000000000000")}, MSGLEN)},
+			},
+		},
+		{
+			descr: "1st mf//BOM",
+			input: "19 \xEF\xBB\xBF<1>1 - - - - - -",
+			// results w/o best effort
+			results: []syslog.Result{
+				{
+					Error: getPriorityError(0),
+				},
+			},
+			// results with best effort
+			bestEffortResults: []syslog.Result{
+				{
+					Error: getPriorityError(0),
+				},
+			},
+		},
+		{
+			descr: "1st mf//leading whitespace/2nd ok",
+			input: "17  <1>1 - - - - - -16 <2>1 - - - - - -",
+			// results w/o best effort
+			results: []syslog.Result{
+				{
+					Error: getPriorityError(0),
+				},
+			},
+			// results with best effort
+			bestEffortResults: []syslog.Result{
+				{
+					Error: getPriorityError(0),
+				},
+				{
+					Message: (&rfc5424.SyslogMessage{}).SetPriority(2).SetVersion(1),
+				},
+			},
+		},
+		{
+			descr: "1st mf//digits",
+			input: "5 12345",
+			// results w/o best effort
+			results: []syslog.Result{
+				{
+					Error: getPriorityError(0),
+				},
+			},
+			// results with best effort
+			bestEffortResults: []syslog.Result{
+				{
+					Error: getPriorityError(0),
+				},
+			},
+		},
 	}
 }
 
@@ -646,3 +738,44 @@ func TestParserBestEffortOption(t *testing.T) {
 	p2 := NewParser(syslog.WithBestEffort()).(syslog.BestEfforter)
 	assert.True(t, p2.HasBestEffort())
 }
+
+// recorder is a machine recording its inputs.
+type recorder struct {
+	inputs []string
+}
+
+func (r *recorder) Parse(input []byte) (syslog.Message, error) {
+	r.inputs = append(r.inputs, string(input))
+	return nil, nil
+}
+
+func (r *recorder) WithBestEffort() {}
+
+func (r *recorder) HasBestEffort() bool {
+	return false
+}
+
+func TestParserWithMachine(t *testing.T) {
+	// Frames are handed to the machine as they are, whatever their content
+	r := &recorder{}
+	input := "19 \xEF\xBB\xBF<1>1 - - - - - -2   3 \x00\n\t9 123 <13>x"
+	NewParser(WithMachine(r)).Parse(strings.NewReader(input))
+	assert.Equal(t, []string{"\xEF\xBB\xBF<1>1 - - - - - -", "  ", "\x00\n\t", "123 <13>x"}, r.inputs)
+
+	// RFC3164 payloads
+	var res []syslog.Result
+	bsd := "<13>Dec  2 16:31:03 host app: hello"
+	input = fmt.Sprintf("%d %s%d %s", len(bsd), bsd, len(bsd), bsd)
+	NewParser(
+		WithMachine(rfc3164.NewMachine(rfc3164.WithYear(rfc3164.Year{YYYY: 2019}))),
+		syslog.WithListener(func(r *syslog.Result) {
+			res = append(res, *r)
+		}),
+	).Parse(strings.NewReader(input))
+	assert.Len(t, res, 2)
+	for _, r := range res {
+		assert.Nil(t, r.Error)
+		assert.Equal(t, "host", *r.Message.(*rfc3164.SyslogMessage).Hostname)
+		assert.Equal(t, "hello", *r.Message.(*rfc3164.SyslogMessage).Message)
+	}
+}
@@ -4,50 +4,45 @@ import (
 	"bufio"
 	"bytes"
 	"io"
-	"strconv"
 )
 
-// eof represents a marker byte for the end of the reader
-var eof = byte(0)
-
 // ws represents the whitespace
 var ws = byte(32)
 
-// lt represents the "<" character
-var lt = byte(60)
+// maxMsgLenDigits is the maximum number of digits of a MSGLEN, so that it always fits into an int64
+const maxMsgLenDigits = 18
 
 // isDigit returns true if the byte represents a number in [0,9]
 func isDigit(ch byte) bool {
-	return (ch >= 47 && ch <= 57)
+	return ch >= '0' && ch <= '9'
 }
 
-// isNonZeroDigit returns true if the byte represents a number in ]0,9]
+// isNonZeroDigit returns true if the byte represents a number in [1,9]
 func isNonZeroDigit(ch byte) bool {
-	return (ch >= 48 && ch <= 57)
+	return ch >= '1' && ch <= '9'
 }
 
 // Scanner represents the lexical scanner for octet counting transport.
 type Scanner struct {
-	r      *bufio.Reader
-	msglen uint64
-	ready  bool
+	r         *bufio.Reader
+	msglen    uint64
+	ready     bool
+	maxLength int
 }
 
 // NewScanner returns a pointer to a new instance of Scanner.
 func NewScanner(r io.Reader, maxLength int) *Scanner {
 	return &Scanner{
-		r: bufio.NewReaderSize(r, maxLength+20), // max uint64 is 19 characters + a space
+		r:         bufio.NewReaderSize(r, maxLength+maxMsgLenDigits+1), // MSGLEN + a space
+		maxLength: maxLength,
 	}
 }
 
 // read reads the next byte from the buffered reader
-// it returns the byte(0) if an error occurs (or io.EOF is returned)
-func (s *Scanner) read() byte {
+// it returns false if an error occurs (or io.EOF is returned)
+func (s *Scanner) read() (byte, bool) {
 	b, err := s.r.ReadByte()
-	if err != nil {
-		return eof
-	}
-	return b
+	return b, err == nil
 }
 
 // unread places the previously read byte back on the reader
@@ -56,9 +51,27 @@ func (s *Scanner) unread() {
 }
 
 // Scan returns the next token.
+//
+// After a MSGLEN and a WS the next MSGLEN octets are a SYSLOGMSG, whatever they are (eg., a BOM, spaces, or a RFC3164 message),
+// since RFC6587 only constrains the frame length.
+// When the MSGLEN exceeds the maximum length the octets after it are scanned as usual, instead.
 func (s *Scanner) Scan() (tok Token) {
+	if s.ready && s.msglen > 0 {
+		if s.msglen <= uint64(s.maxLength) {
+			return s.scanSyslogMsg()
+		}
+		s.ready = false
+		s.msglen = 0
+	}
+
 	// Read the next byte.
-	b := s.read()
+	b, ok := s.read()
+	if !ok {
+		s.ready = false
+		return Token{
+			typ: EOF,
+		}
+	}
 
 	if isNonZeroDigit(b) {
 		s.unread()
@@ -68,22 +81,12 @@ func (s *Scanner) Scan() (tok Token) {
 
 	// Otherwise read the individual character
 	switch b {
-	case eof:
-		s.ready = false
-		return Token{
-			typ: EOF,
-		}
 	case ws:
 		s.ready = true
 		return Token{
 			typ: WS,
 			lit: []byte{ws},
 		}
-	case lt:
-		if s.msglen > 0 && s.ready {
-			s.unread()
-			return s.scanSyslogMsg()
-		}
 	}
 
 	return Token{
@@ -92,26 +95,39 @@ func (s *Scanner) Scan() (tok Token) {
 	}
 }
 
+// scanMsgLen scans a MSGLEN (ie., NONZERO-DIGIT *DIGIT) of at most maxMsgLenDigits digits.
+//
+// Longer ones are ILLEGAL tokens.
 func (s *Scanner) scanMsgLen() Token {
 	// Create a buffer and read the current character into it
 	var buf bytes.Buffer
-	buf.WriteByte(s.read())
+	first, _ := s.read()
+	buf.WriteByte(first)
+	msglen := uint64(first - '0')
 
 	// Read every subsequent digit character into the buffer
 	// Non-digit characters and EOF will cause the loop to exit
 	for {
-		if b := s.read(); b == eof {
+		b, ok := s.read()
+		if !ok {
 			break
-		} else if !isDigit(b) {
+		}
+		if !isDigit(b) {
 			s.unread()
 			break
-		} else {
-			buf.WriteByte(b)
 		}
+		buf.WriteByte(b)
+		if buf.Len() > maxMsgLenDigits {
+			s.msglen = 0
+			return Token{
+				typ: ILLEGAL,
+				lit: buf.Bytes(),
+			}
+		}
+		msglen = msglen*10 + uint64(b-'0')
 	}
 
-	msglen := buf.String()
-	s.msglen, _ = strconv.ParseUint(msglen, 10, 64)
+	s.msglen = msglen
 
 	return Token{
 		typ: MSGLEN,
@@ -0,0 +1,78 @@
+//go:build go1.18
+// +build go1.18
+
+package octetcounting
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+func FuzzScan(f *testing.F) {
+	for _, seed := range []string{
+		"16 <1>1 - - - - - -",
+		"0 ",
+		"1/2 ab",
+		"012 abc",
+		"999999999999999999 x",
+		"1000000000000000000 x",
+		"3 \x00ab2 cd",
+		"19 \xEF\xBB\xBF<1>1 - - - - - -",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		s := NewScanner(bytes.NewReader(input), 64)
+		// Every token but EOF consumes at least an octet
+		for i := 0; i <= len(input)+1; i++ {
+			tok := s.Scan()
+			switch tok.typ {
+			case EOF:
+				return
+			case MSGLEN:
+				if len(tok.lit) == 0 || len(tok.lit) > maxMsgLenDigits || tok.lit[0] == '0' {
+					t.Fatalf("invalid MSGLEN %q", tok.lit)
+				}
+				n, err := strconv.ParseUint(string(tok.lit), 10, 64)
+				if err != nil || n != s.msglen {
+					t.Fatalf("MSGLEN %q scanned as %d", tok.lit, s.msglen)
+				}
+			case SYSLOGMSG:
+				if len(tok.lit) == 0 || len(tok.lit) > 64 {
+					t.Fatalf("invalid SYSLOGMSG of %d octets", len(tok.lit))
+				}
+			}
+		}
+		t.Fatalf("no EOF after scanning %d octets", len(input))
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"16 <1>1 - - - - - -",
+		"16 <1>1 - - - - - -17 <2>12 A B C D E -",
+		"0 ",
+		"1/2 ab",
+		"5 12345",
+	} {
+		f.Add([]byte(seed))
+	}
+	for _, tc := range getTestCases() {
+		f.Add([]byte(tc.input))
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		for _, opts := range [][]syslog.ParserOption{nil, {syslog.WithBestEffort()}} {
+			opts = append(opts, syslog.WithMaxMessageLength(64), syslog.WithListener(func(res *syslog.Result) {
+				if res.Message == nil && res.Error == nil {
+					t.Fatal("empty result")
+				}
+			}))
+			NewParser(opts...).Parse(bytes.NewReader(input))
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package octetcounting
+
+import (
+	"strings"
+	"testing"
+
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+// scanAll returns the tokens of the input, up to the first EOF or ILLEGAL one (included).
+func scanAll(input string) []string {
+	s := NewScanner(strings.NewReader(input), 8192)
+	var out []string
+	for i := 0; i <= len(input); i++ {
+		tok := s.Scan()
+		out = append(out, tok.String())
+		if tok.typ == EOF || tok.typ == ILLEGAL {
+			break
+		}
+	}
+
+	return out
+}
+
+func TestScan(t *testing.T) {
+	cases := []struct {
+		input  string
+		tokens []string
+	}{
+		{"3 abc", []string{"MSGLEN(3)", "WS", "SYSLOGMSG(abc)", "EOF"}},
+		{"10 0123456789", []string{"MSGLEN(10)", "WS", "SYSLOGMSG(0123456789)", "EOF"}},
+		{"0 ", []string{"ILLEGAL(0)"}},
+		{"012 abc", []string{"ILLEGAL(0)"}},
+		{"1/2 ab", []string{"MSGLEN(1)", "ILLEGAL(/)"}},
+		{"1:2 ab", []string{"MSGLEN(1)", "ILLEGAL(:)"}},
+		{"/ a", []string{"ILLEGAL(/)"}},
+		{"999999999999999999 ", []string{"MSGLEN(999999999999999999)", "WS", "EOF"}},
+		{"1999999999999999999 ", []string{"ILLEGAL(1999999999999999999)"}},
+		{"99999999999999999999999 ", []string{"ILLEGAL(9999999999999999999)"}},
+		{"3 \x00ab", []string{"MSGLEN(3)", "WS", "SYSLOGMSG(\x00ab)", "EOF"}},
+		{"\x00", []string{"ILLEGAL(\x00)"}},
+		{"3", []string{"MSGLEN(3)", "EOF"}},
+		{"", []string{"EOF"}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc.input, 30), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.tokens, scanAll(tc.input))
+		})
+	}
+}
+
+func TestScanMaxLength(t *testing.T) {
+	s := NewScanner(strings.NewReader("00005 abcde3 abc"), 4)
+	var out []string
+	for tok := s.Scan(); tok.typ != EOF; tok = s.Scan() {
+		out = append(out, tok.String())
+	}
+	// The octets after a too long MSGLEN are not a SYSLOGMSG
+	assert.Equal(t, []string{
+		"ILLEGAL(0)", "ILLEGAL(0)", "ILLEGAL(0)", "ILLEGAL(0)", "MSGLEN(5)", "WS",
+		"ILLEGAL(a)", "ILLEGAL(b)", "ILLEGAL(c)", "ILLEGAL(d)", "ILLEGAL(e)", "MSGLEN(3)", "WS", "SYSLOGMSG(abc)",
+	}, out)
+}
+
+func TestScanMsgLen(t *testing.T) {
+	s := NewScanner(strings.NewReader("123456789012345678 "), 8192)
+	tok := s.Scan()
+	assert.Equal(t, MSGLEN, tok.typ)
+	assert.Equal(t, uint64(123456789012345678), s.msglen)
+
+	s = NewScanner(strings.NewReader("1234567890123456789 "), 8192)
+	tok = s.Scan()
+	assert.Equal(t, ILLEGAL, tok.typ)
+	assert.Equal(t, uint64(0), s.msglen)
+}
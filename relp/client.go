@@ -0,0 +1,355 @@
+package relp
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWindowSize = 128
+	defaultTimeout    = 10 * time.Second
+)
+
+var (
+	// ErrClosed is returned when using a closed client.
+	ErrClosed = errors.New("RELP client closed")
+	// ErrUnexpectedResponse is returned when the server responds to a transaction that is not in flight.
+	ErrUnexpectedResponse = errors.New("RELP response to an unknown transaction")
+)
+
+// ClientOption represents an option for the Client.
+type ClientOption func(*Client)
+
+// WithWindowSize sets the maximum number of messages in flight, ie. sent but not acknowledged yet (128 by default).
+func WithWindowSize(size int) ClientOption {
+	return func(c *Client) {
+		if size > 0 {
+			c.window = size
+		}
+	}
+}
+
+// WithTimeout sets how long the client waits for the server (10 seconds by default).
+//
+// It bounds connecting, opening a session, writing, and receiving the response to the oldest message in flight:
+// on expiration the connection is broken, so that the messages in flight are resent on a new one.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// WithDialer sets the function that connects to the server (a TCP dialer with the timeout of the client by default).
+//
+// It can be used to connect through TLS. The dialer is responsible for its own timeout.
+func WithDialer(dial func() (net.Conn, error)) ClientOption {
+	return func(c *Client) {
+		if dial != nil {
+			c.dial = dial
+		}
+	}
+}
+
+type transaction struct {
+	txnr uint64
+	data []byte
+}
+
+// Client sends syslog messages over RELP.
+//
+// It keeps the messages sent until the server acknowledges them, so that it resends them on a new connection when the current one breaks.
+// Thus messages are delivered at least once, in order, but they can be duplicated.
+// It is safe for concurrent use.
+type Client struct {
+	window  int
+	timeout time.Duration
+	dial    func() (net.Conn, error)
+
+	mu         sync.Mutex
+	cond       *sync.Cond
+	conn       net.Conn
+	connecting bool // Whether a goroutine is opening a session, without holding the lock
+	next       uint64
+	inflight   []*transaction
+	refused    error // The last response refusing a message
+	closed     bool
+}
+
+// Dial creates a client connected to the server at the TCP address.
+func Dial(address string, options ...ClientOption) (*Client, error) {
+	c := &Client{
+		window:  defaultWindowSize,
+		timeout: defaultTimeout,
+	}
+	c.dial = func() (net.Conn, error) {
+		return net.DialTimeout("tcp", address, c.timeout)
+	}
+	c.cond = sync.NewCond(&c.mu)
+	for _, opt := range options {
+		opt(c)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Send sends a syslog message, blocking while the window of messages in flight is full.
+//
+// It reconnects when the connection is broken, returning an error only when reconnecting fails.
+// The message is kept anyway, so that a successful Send, Flush, or Close resends it.
+func (c *Client) Send(message []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		if c.closed {
+			return ErrClosed
+		}
+		if c.conn == nil {
+			if err := c.connect(); err != nil {
+				return err
+			}
+		}
+		if len(c.inflight) < c.window {
+			break
+		}
+		c.cond.Wait()
+	}
+
+	t := &transaction{data: append([]byte(nil), message...)}
+	c.inflight = append(c.inflight, t)
+	c.send(t)
+
+	return nil
+}
+
+// Flush waits until the server acknowledges all the messages in flight, reconnecting when the connection breaks.
+//
+// It returns a *Response error when the server has refused messages (which are not resent) since the last Flush.
+func (c *Client) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.flush()
+}
+
+// Close flushes the messages in flight, then it closes the session.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	err := c.flush()
+	c.closed = true
+	if c.conn == nil {
+		return err
+	}
+
+	// The response to the close command is not awaited, since the server closes the connection anyway
+	conn := c.conn
+	c.conn = nil
+	f := &Frame{TxNr: c.txnr(), Command: CommandClose}
+	conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	if _, werr := conn.Write(f.AppendTo(nil)); err == nil {
+		err = werr
+	}
+	conn.Close()
+	c.cond.Broadcast()
+
+	return err
+}
+
+func (c *Client) flush() error {
+	for len(c.inflight) > 0 {
+		if c.closed {
+			return ErrClosed
+		}
+		if c.conn == nil {
+			if err := c.connect(); err != nil {
+				return err
+			}
+			continue
+		}
+		c.cond.Wait()
+	}
+	err := c.refused
+	c.refused = nil
+
+	return err
+}
+
+// connect opens a session on a new connection, then it resends the messages in flight.
+//
+// It must be called holding the lock, which it releases while connecting (the other callers wait for it meanwhile).
+func (c *Client) connect() error {
+	for c.connecting {
+		c.cond.Wait()
+	}
+	if c.closed {
+		return ErrClosed
+	}
+	if c.conn != nil {
+		return nil
+	}
+
+	c.connecting = true
+	c.mu.Unlock()
+	conn, r, err := c.open()
+	c.mu.Lock()
+	c.connecting = false
+	c.cond.Broadcast()
+	if err != nil {
+		return err
+	}
+	if c.closed {
+		conn.Close()
+		return ErrClosed
+	}
+
+	// Transaction numbers restart with every session, the open command being the first one
+	c.next = 2
+	c.conn = conn
+	go c.receive(conn, r)
+	for _, t := range c.inflight {
+		c.send(t)
+	}
+
+	return nil
+}
+
+// open connects to the server and opens a session, within the timeout.
+func (c *Client) open() (net.Conn, *bufio.Reader, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(c.timeout))
+	r := bufio.NewReader(conn)
+	open := &Frame{TxNr: 1, Command: CommandOpen, Data: offers()}
+	_, err = conn.Write(open.AppendTo(nil))
+	var f *Frame
+	if err == nil {
+		f, err = ReadFrame(r, defaultMaxFrame)
+	}
+	if err == nil && (f.Command != CommandResponse || f.TxNr != open.TxNr) {
+		err = ErrFrame
+	}
+	var res *Response
+	if err == nil {
+		res, err = ParseResponse(f.Data)
+	}
+	if err == nil && !res.OK() {
+		err = res
+	}
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	return conn, r, nil
+}
+
+// send writes the transaction on the current connection, breaking it on errors.
+//
+// The server must respond within the timeout, unless other messages are already waiting for a response.
+// It must be called holding the lock.
+func (c *Client) send(t *transaction) {
+	if c.conn == nil {
+		return
+	}
+	t.txnr = c.txnr()
+	f := &Frame{TxNr: t.txnr, Command: CommandSyslog, Data: t.data}
+	if t == c.inflight[0] {
+		c.conn.SetReadDeadline(time.Now().Add(c.timeout))
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	if _, err := c.conn.Write(f.AppendTo(nil)); err != nil {
+		c.disconnect(c.conn)
+	}
+}
+
+// receive handles the responses of the server, until the connection breaks.
+func (c *Client) receive(conn net.Conn, r *bufio.Reader) {
+	for {
+		f, err := ReadFrame(r, defaultMaxFrame)
+		if err == nil && f.Command == CommandServerClose {
+			err = ErrClosed
+		}
+		if err == nil && f.Command != CommandResponse {
+			err = ErrFrame
+		}
+
+		c.mu.Lock()
+		if err == nil {
+			err = c.acknowledge(f)
+		}
+		if err != nil {
+			c.disconnect(conn)
+			c.mu.Unlock()
+			return
+		}
+		// The timeout restarts for the next message in flight
+		if len(c.inflight) > 0 {
+			conn.SetReadDeadline(time.Now().Add(c.timeout))
+		} else {
+			conn.SetReadDeadline(time.Time{})
+		}
+		c.cond.Broadcast()
+		c.mu.Unlock()
+	}
+}
+
+// acknowledge removes the transaction acknowledged by the response from the ones in flight.
+func (c *Client) acknowledge(f *Frame) error {
+	res, err := ParseResponse(f.Data)
+	if err != nil {
+		return err
+	}
+	for i, t := range c.inflight {
+		if t.txnr != f.TxNr {
+			continue
+		}
+		// Messages refused by the server are not resent
+		c.inflight = append(c.inflight[:i], c.inflight[i+1:]...)
+		if !res.OK() {
+			c.refused = res
+		}
+		return nil
+	}
+
+	return ErrUnexpectedResponse
+}
+
+// disconnect closes the connection, if it is still the current one.
+//
+// It must be called holding the lock.
+func (c *Client) disconnect(conn net.Conn) {
+	conn.Close()
+	if c.conn != conn {
+		return
+	}
+	c.conn = nil
+	c.cond.Broadcast()
+}
+
+// txnr returns the next transaction number.
+func (c *Client) txnr() uint64 {
+	n := c.next
+	c.next = c.next%maxTxNr + 1
+
+	return n
+}
@@ -0,0 +1,222 @@
+// Package relp implements the Reliable Event Logging Protocol (RELP) of rsyslog.
+//
+// RELP carries syslog messages over TCP within frames having a transaction number,
+// which the receiver acknowledges once it has processed them, so that no message is lost when connections break:
+//
+//	TXNR SP COMMAND SP DATALEN [SP DATA] LF
+//
+// The package provides a Server, delivering the messages to a listener and acknowledging them only after the listener returns,
+// and a Client, keeping a window of unacknowledged messages that it resends on reconnection.
+package relp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Commands.
+const (
+	CommandOpen        = "open"
+	CommandSyslog      = "syslog"
+	CommandClose       = "close"
+	CommandResponse    = "rsp"
+	CommandServerClose = "serverclose"
+)
+
+const (
+	maxTxNr         = 999999999
+	maxCommandLen   = 32
+	maxDataLenLen   = 9
+	defaultMaxFrame = 131072
+	version         = "0"
+	software        = "go-syslog,,https://github.com/influxdata/go-syslog"
+)
+
+var (
+	// ErrFrame is returned for malformed frames.
+	ErrFrame = errors.New("expecting a RELP frame made of transaction number, command, data length, and data")
+	// ErrFrameTooLong is returned for frames whose data is longer than the maximum length.
+	ErrFrameTooLong = errors.New("RELP frame data exceeding the maximum length")
+)
+
+// Frame represents a RELP frame.
+type Frame struct {
+	TxNr    uint64
+	Command string
+	Data    []byte
+}
+
+// ReadFrame reads a frame whose data is at most max octets.
+func ReadFrame(r *bufio.Reader, max int) (*Frame, error) {
+	txnr, err := readField(r, 10, isDigit)
+	if err != nil {
+		return nil, err
+	}
+	command, err := readField(r, maxCommandLen, isCommandChar)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	f := &Frame{Command: command}
+	if f.TxNr, err = strconv.ParseUint(txnr, 10, 64); err != nil || f.TxNr > maxTxNr {
+		return nil, ErrFrame
+	}
+
+	// The data length is followed by a space when there is data, by the trailer otherwise
+	var b []byte
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if !isDigit(c) {
+			if len(b) == 0 || (c != ' ' && c != '\n') {
+				return nil, ErrFrame
+			}
+			r.UnreadByte()
+			break
+		}
+		if len(b) == maxDataLenLen {
+			return nil, ErrFrame
+		}
+		b = append(b, c)
+	}
+	n, _ := strconv.Atoi(string(b))
+	if n > max {
+		return nil, ErrFrameTooLong
+	}
+
+	sep, _ := r.ReadByte()
+	if n > 0 {
+		if sep != ' ' {
+			return nil, ErrFrame
+		}
+		f.Data = make([]byte, n)
+		if _, err := io.ReadFull(r, f.Data); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if sep, err = r.ReadByte(); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+	}
+	if sep != '\n' {
+		return nil, ErrFrame
+	}
+
+	return f, nil
+}
+
+// AppendTo appends the wire representation of the frame to dst and returns the extended buffer.
+func (f *Frame) AppendTo(dst []byte) []byte {
+	dst = strconv.AppendUint(dst, f.TxNr, 10)
+	dst = append(dst, ' ')
+	dst = append(dst, f.Command...)
+	dst = append(dst, ' ')
+	dst = strconv.AppendInt(dst, int64(len(f.Data)), 10)
+	if len(f.Data) > 0 {
+		dst = append(dst, ' ')
+		dst = append(dst, f.Data...)
+	}
+
+	return append(dst, '\n')
+}
+
+// Response represents the data of a rsp frame (ie., a code, a human readable message, and optional data).
+type Response struct {
+	Code    int
+	Message string
+	Data    []byte
+}
+
+// Error returns the textual representation of non successful responses.
+func (r *Response) Error() string {
+	return fmt.Sprintf("RELP response %d %s", r.Code, r.Message)
+}
+
+// OK tells whether the response is a successful one.
+func (r *Response) OK() bool {
+	return r.Code >= 200 && r.Code < 300
+}
+
+// ParseResponse parses the data of a rsp frame.
+func ParseResponse(data []byte) (*Response, error) {
+	if len(data) < 3 || !isDigit(data[0]) || !isDigit(data[1]) || !isDigit(data[2]) || (len(data) > 3 && data[3] != ' ' && data[3] != '\n') {
+		return nil, ErrFrame
+	}
+	r := &Response{}
+	r.Code, _ = strconv.Atoi(string(data[:3]))
+
+	rest := data[3:]
+	if len(rest) > 0 && rest[0] == ' ' {
+		rest = rest[1:]
+	}
+	for i, c := range rest {
+		if c == '\n' {
+			r.Message = string(rest[:i])
+			r.Data = rest[i+1:]
+			return r, nil
+		}
+	}
+	r.Message = string(rest)
+
+	return r, nil
+}
+
+func (r *Response) appendTo(dst []byte) []byte {
+	dst = strconv.AppendInt(dst, int64(r.Code), 10)
+	if r.Message != "" {
+		dst = append(dst, ' ')
+		dst = append(dst, r.Message...)
+	}
+	if len(r.Data) > 0 {
+		dst = append(dst, '\n')
+		dst = append(dst, r.Data...)
+	}
+
+	return dst
+}
+
+// offers returns the offers of the open command, and of its response.
+func offers() []byte {
+	return []byte("relp_version=" + version + "\nrelp_software=" + software + "\ncommands=" + CommandSyslog)
+}
+
+// readField reads a field of at most max characters followed by a space.
+func readField(r *bufio.Reader, max int, valid func(byte) bool) (string, error) {
+	var b []byte
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			if len(b) > 0 {
+				return "", unexpectedEOF(err)
+			}
+			return "", err
+		}
+		if c == ' ' && len(b) > 0 {
+			return string(b), nil
+		}
+		if !valid(c) || len(b) == max {
+			return "", ErrFrame
+		}
+		b = append(b, c)
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isCommandChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,72 @@
+package relp
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFrame(t *testing.T) {
+	cases := []struct {
+		input string
+		frame *Frame
+		err   error
+	}{
+		{"1 open 5 hello\n", &Frame{1, "open", []byte("hello")}, nil},
+		{"2 syslog 13 <13>1 - - -\nx\n", &Frame{2, "syslog", []byte("<13>1 - - -\nx")}, nil},
+		{"3 close 0\n", &Frame{3, "close", nil}, nil},
+		{"0 serverclose 0\n", &Frame{0, "serverclose", nil}, nil},
+		{"", nil, io.EOF},
+		{"1 open", nil, io.ErrUnexpectedEOF},
+		{"1 syslog 10 short\n", nil, io.ErrUnexpectedEOF},
+		{"1 syslog 5 hello", nil, io.ErrUnexpectedEOF},
+		{"x open 0\n", nil, ErrFrame},
+		{"1000000000 open 0\n", nil, ErrFrame},
+		{"1 op3n 0\n", nil, ErrFrame},
+		{"1 open 5hello\n", nil, ErrFrame},
+		{"1 open 0 \n", nil, ErrFrame},
+		{"1 open 5 hello world\n", nil, ErrFrame},
+		{"1 open \n", nil, ErrFrame},
+		{"1 syslog 17 hello world hello\n", nil, ErrFrameTooLong},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc.input, 30), func(t *testing.T) {
+			t.Parallel()
+
+			f, err := ReadFrame(bufio.NewReader(strings.NewReader(tc.input)), 16)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.frame, f)
+			if f != nil {
+				assert.Equal(t, tc.input, string(f.AppendTo(nil)))
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	r, err := ParseResponse([]byte("200 OK"))
+	assert.Nil(t, err)
+	assert.Equal(t, &Response{Code: 200, Message: "OK"}, r)
+	assert.True(t, r.OK())
+
+	r, err = ParseResponse([]byte("200 OK\nrelp_version=0\ncommands=syslog"))
+	assert.Nil(t, err)
+	assert.Equal(t, &Response{Code: 200, Message: "OK", Data: []byte("relp_version=0\ncommands=syslog")}, r)
+	assert.Equal(t, "200 OK\nrelp_version=0\ncommands=syslog", string(r.appendTo(nil)))
+
+	r, err = ParseResponse([]byte("500"))
+	assert.Nil(t, err)
+	assert.False(t, r.OK())
+	assert.Equal(t, "RELP response 500 ", r.Error())
+
+	_, err = ParseResponse([]byte("20 OK"))
+	assert.Equal(t, ErrFrame, err)
+	_, err = ParseResponse([]byte("2000"))
+	assert.Equal(t, ErrFrame, err)
+}
@@ -0,0 +1,302 @@
+package relp
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+)
+
+// collector collects the messages delivered by a server.
+type collector struct {
+	mu       sync.Mutex
+	messages []string
+	errors   int
+}
+
+func (c *collector) listener(res *syslog.Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if res.Error != nil {
+		c.errors++
+		return
+	}
+	c.messages = append(c.messages, *res.Message.(*rfc5424.SyslogMessage).Message)
+}
+
+func (c *collector) get() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.messages...)
+}
+
+// serve starts a server on a loopback address, returning it with its address.
+func serve(t *testing.T, address string, listener syslog.ParserListener) (*Server, string, chan error) {
+	t.Helper()
+	l, err := net.Listen("tcp", address)
+	assert.Nil(t, err)
+	s := NewServer(listener)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(l)
+	}()
+
+	return s, l.Addr().String(), done
+}
+
+func message(i int) []byte {
+	return []byte(fmt.Sprintf("<13>1 - host app - - - message %d", i))
+}
+
+func TestClientServer(t *testing.T) {
+	for _, window := range []int{1, 3, 128} {
+		window := window
+		t.Run(fmt.Sprintf("window %d", window), func(t *testing.T) {
+			c := &collector{}
+			s, address, done := serve(t, "127.0.0.1:0", c.listener)
+
+			client, err := Dial(address, WithWindowSize(window))
+			assert.Nil(t, err)
+			var expected []string
+			for i := 0; i < 50; i++ {
+				assert.Nil(t, client.Send(message(i)))
+				expected = append(expected, fmt.Sprintf("message %d", i))
+			}
+			assert.Nil(t, client.Send([]byte("not a syslog message")))
+			assert.Nil(t, client.Close())
+			assert.Nil(t, client.Close())
+			assert.Equal(t, ErrClosed, client.Send(message(0)))
+
+			assert.Equal(t, expected, c.get())
+			assert.Equal(t, 1, c.errors)
+			assert.Nil(t, s.Close())
+			assert.Nil(t, <-done)
+		})
+	}
+}
+
+// flakyServer acknowledges only the first message of its first connection, then it closes it.
+func flakyServer(l net.Listener, received chan<- string) {
+	for conn := 0; ; conn++ {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		r := bufio.NewReader(c)
+		for n := 0; ; n++ {
+			f, err := ReadFrame(r, defaultMaxFrame)
+			if err != nil {
+				break
+			}
+			if f.Command == CommandSyslog {
+				received <- fmt.Sprintf("%d:%s", conn, f.Data)
+			}
+			if conn == 0 && n == 2 {
+				break
+			}
+			if f.Command == CommandClose {
+				break
+			}
+			c.Write(response(f.TxNr, &Response{Code: 200, Message: "OK"}).AppendTo(nil))
+		}
+		c.Close()
+	}
+}
+
+func TestClientResend(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+	received := make(chan string, 16)
+	go flakyServer(l, received)
+
+	client, err := Dial(l.Addr().String(), WithWindowSize(2))
+	assert.Nil(t, err)
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, client.Send([]byte(fmt.Sprintf("m%d", i))))
+	}
+	assert.Nil(t, client.Flush())
+	assert.Nil(t, client.Close())
+	close(received)
+
+	var first, second []string
+	for r := range received {
+		if r[0] == '0' {
+			first = append(first, r)
+		} else {
+			second = append(second, r)
+		}
+	}
+	// The messages not acknowledged on the first connection are resent, in order
+	assert.Equal(t, []string{"0:m0", "0:m1"}, first[:2])
+	assert.Equal(t, []string{"1:m1", "1:m2"}, second)
+}
+
+func TestServerClose(t *testing.T) {
+	c := &collector{}
+	s, address, done := serve(t, "127.0.0.1:0", c.listener)
+
+	client, err := Dial(address)
+	assert.Nil(t, err)
+	assert.Nil(t, client.Send(message(1)))
+	assert.Nil(t, client.Flush())
+
+	// The clients are notified with the serverclose command
+	assert.Nil(t, s.Close())
+	assert.Nil(t, <-done)
+	assert.Nil(t, s.Close())
+
+	// Messages sent while the server is down are resent once it is back
+	deadline := time.Now().Add(5 * time.Second)
+	for client.Send(message(2)) == nil {
+		assert.True(t, time.Now().Before(deadline))
+		time.Sleep(10 * time.Millisecond)
+	}
+	s, _, done = serve(t, address, c.listener)
+	assert.Nil(t, client.Flush())
+	assert.Nil(t, client.Close())
+
+	assert.Equal(t, []string{"message 1", "message 2"}, c.get())
+	assert.Nil(t, s.Close())
+	assert.Nil(t, <-done)
+}
+
+func TestServerProtocol(t *testing.T) {
+	c := &collector{}
+	s, address, done := serve(t, "127.0.0.1:0", c.listener)
+	defer func() {
+		s.Close()
+		<-done
+	}()
+
+	exchange := func(conn net.Conn, r *bufio.Reader, request string) *Frame {
+		_, err := conn.Write([]byte(request))
+		assert.Nil(t, err)
+		f, err := ReadFrame(r, defaultMaxFrame)
+		assert.Nil(t, err)
+		return f
+	}
+
+	// Sessions start with the open command
+	conn, err := net.Dial("tcp", address)
+	assert.Nil(t, err)
+	r := bufio.NewReader(conn)
+	f := exchange(conn, r, "1 syslog 5 hello\n")
+	assert.Equal(t, "rsp", f.Command)
+	assert.Equal(t, "500 session not opened", string(f.Data))
+	_, err = ReadFrame(r, defaultMaxFrame)
+	assert.Error(t, err)
+	conn.Close()
+
+	conn, err = net.Dial("tcp", address)
+	assert.Nil(t, err)
+	defer conn.Close()
+	r = bufio.NewReader(conn)
+	f = exchange(conn, r, "1 open 14 relp_version=0\n")
+	assert.Equal(t, uint64(1), f.TxNr)
+	res, err := ParseResponse(f.Data)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, res.Code)
+	assert.Contains(t, string(res.Data), "commands=syslog")
+
+	f = exchange(conn, r, "2 starttls 0\n")
+	assert.Equal(t, uint64(2), f.TxNr)
+	assert.Equal(t, "500 unknown command", string(f.Data))
+
+	f = exchange(conn, r, "3 syslog 32 <13>1 - host app - - - message 1\n")
+	assert.Equal(t, uint64(3), f.TxNr)
+	assert.Equal(t, "200 OK", string(f.Data))
+	assert.Equal(t, []string{"message 1"}, c.get())
+
+	f = exchange(conn, r, "4 close 0\n")
+	assert.Equal(t, uint64(4), f.TxNr)
+	assert.Equal(t, "200 OK", string(f.Data))
+}
+
+func TestClientRefused(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		r := bufio.NewReader(c)
+		for {
+			f, err := ReadFrame(r, defaultMaxFrame)
+			if err != nil {
+				return
+			}
+			res := &Response{Code: 200, Message: "OK"}
+			if f.Command == CommandSyslog {
+				res = &Response{Code: 500, Message: "no space left"}
+			}
+			c.Write(response(f.TxNr, res).AppendTo(nil))
+		}
+	}()
+
+	client, err := Dial(l.Addr().String())
+	assert.Nil(t, err)
+	assert.Nil(t, client.Send(message(1)))
+	assert.Equal(t, &Response{Code: 500, Message: "no space left"}, client.Flush())
+	assert.Nil(t, client.Flush())
+	assert.Nil(t, client.Close())
+}
+
+// mutedServer responds to the open commands (when open is true) and to the syslog commands, except for the ones of its first connection.
+func mutedServer(l net.Listener, open bool) {
+	for conn := 0; ; conn++ {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func(c net.Conn, conn int) {
+			defer c.Close()
+			r := bufio.NewReader(c)
+			for {
+				f, err := ReadFrame(r, defaultMaxFrame)
+				if err != nil {
+					return
+				}
+				if (f.Command == CommandOpen && open) || (f.Command == CommandSyslog && conn > 0) {
+					c.Write(response(f.TxNr, &Response{Code: 200, Message: "OK"}).AppendTo(nil))
+				}
+			}
+		}(c, conn)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+	go mutedServer(l, false)
+
+	// The session is never opened
+	start := time.Now()
+	_, err = Dial(l.Addr().String(), WithTimeout(100*time.Millisecond))
+	assert.Error(t, err)
+	assert.True(t, err.(net.Error).Timeout())
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	m, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer m.Close()
+	go mutedServer(m, true)
+
+	// The messages not acknowledged in time are resent on a new connection
+	client, err := Dial(m.Addr().String(), WithTimeout(100*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Nil(t, client.Send(message(1)))
+	assert.Nil(t, client.Flush())
+	assert.Nil(t, client.Close())
+}
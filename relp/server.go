@@ -0,0 +1,221 @@
+package relp
+
+import (
+	"bufio"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+)
+
+// ServerOption represents an option for the Server.
+type ServerOption func(*Server)
+
+// WithMachine sets the machine parsing the messages (a best effort RFC5424 parser by default).
+func WithMachine(m syslog.Machine) ServerOption {
+	return func(s *Server) {
+		if m != nil {
+			s.machine = m
+		}
+	}
+}
+
+// WithMaxMessageLength sets the maximum length of the data of the frames (128KiB by default).
+func WithMaxMessageLength(length int) ServerOption {
+	return func(s *Server) {
+		if length > 0 {
+			s.max = length
+		}
+	}
+}
+
+// Server receives syslog messages over RELP.
+type Server struct {
+	machine  syslog.Machine
+	listener syslog.ParserListener
+	max      int
+
+	mu        sync.Mutex // Serializes the parsing and the listener calls
+	connMu    sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[*serverConn]struct{}
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+type serverConn struct {
+	net.Conn
+	mu sync.Mutex // Serializes the writes
+}
+
+func (c *serverConn) write(f *Frame) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.Write(f.AppendTo(nil))
+
+	return err
+}
+
+// NewServer creates a server delivering the results of the parsing of the messages to the listener.
+//
+// The listener is never called concurrently, and the messages are acknowledged only after it returns:
+// a message whose processing is interrupted (eg., by a crash) is resent by the client.
+func NewServer(listener syslog.ParserListener, options ...ServerOption) *Server {
+	s := &Server{
+		machine:   rfc5424.NewParser(rfc5424.WithBestEffort()),
+		listener:  listener,
+		max:       defaultMaxFrame,
+		listeners: map[net.Listener]struct{}{},
+		conns:     map[*serverConn]struct{}{},
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+
+	return s
+}
+
+// ListenAndServe listens on the TCP address and serves the connections, until the server is closed.
+func (s *Server) ListenAndServe(address string) error {
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(l)
+}
+
+// Serve accepts the connections from the listener and serves them, until the server is closed.
+//
+// It returns nil when the server has been closed, the error that stopped it otherwise.
+func (s *Server) Serve(l net.Listener) error {
+	s.connMu.Lock()
+	if s.closed {
+		s.connMu.Unlock()
+		l.Close()
+		return nil
+	}
+	s.listeners[l] = struct{}{}
+	s.connMu.Unlock()
+
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			s.connMu.Lock()
+			closed := s.closed
+			delete(s.listeners, l)
+			s.connMu.Unlock()
+			if closed {
+				return nil
+			}
+			l.Close()
+			return err
+		}
+
+		conn := &serverConn{Conn: c}
+		s.connMu.Lock()
+		if s.closed {
+			s.connMu.Unlock()
+			c.Close()
+			continue
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.connMu.Unlock()
+
+		go s.serve(conn)
+	}
+}
+
+// Close stops the server: it closes the listeners, and it sends a serverclose command to the clients before closing their connections.
+//
+// It waits for the clients not reading their connections for 10 seconds at most.
+func (s *Server) Close() error {
+	s.connMu.Lock()
+	if s.closed {
+		s.connMu.Unlock()
+		return nil
+	}
+	s.closed = true
+	var err error
+	for l := range s.listeners {
+		if lerr := l.Close(); err == nil {
+			err = lerr
+		}
+	}
+	conns := make([]*serverConn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.connMu.Unlock()
+
+	// The clients that do not read hold the server up until the timeout at most
+	deadline := time.Now().Add(defaultTimeout)
+	for _, c := range conns {
+		c.SetWriteDeadline(deadline)
+	}
+	for _, c := range conns {
+		c.write(&Frame{TxNr: 0, Command: CommandServerClose})
+		c.Close()
+	}
+	s.wg.Wait()
+
+	return err
+}
+
+func (s *Server) serve(c *serverConn) {
+	defer func() {
+		s.connMu.Lock()
+		delete(s.conns, c)
+		s.connMu.Unlock()
+		c.Close()
+		s.wg.Done()
+	}()
+
+	r := bufio.NewReader(c)
+	opened := false
+	for {
+		f, err := ReadFrame(r, s.max)
+		if err != nil {
+			return
+		}
+
+		var res *Response
+		switch {
+		case f.Command == CommandOpen && !opened:
+			opened = true
+			res = &Response{Code: 200, Message: "OK", Data: offers()}
+		case !opened:
+			// The session must start with the open command
+			c.write(response(f.TxNr, &Response{Code: 500, Message: "session not opened"}))
+			return
+		case f.Command == CommandSyslog:
+			s.deliver(f.Data)
+			res = &Response{Code: 200, Message: "OK"}
+		case f.Command == CommandClose:
+			c.write(response(f.TxNr, &Response{Code: 200, Message: "OK"}))
+			return
+		default:
+			res = &Response{Code: 500, Message: "unknown command"}
+		}
+		if err := c.write(response(f.TxNr, res)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) deliver(data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg, err := s.machine.Parse(data)
+	if s.listener != nil {
+		s.listener(&syslog.Result{Message: msg, Error: err})
+	}
+}
+
+func response(txnr uint64, r *Response) *Frame {
+	return &Frame{TxNr: txnr, Command: CommandResponse, Data: r.appendTo(nil)}
+}
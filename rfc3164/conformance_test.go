@@ -0,0 +1,38 @@
+package rfc3164
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3"
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the conformance tests")
+
+func TestConformance(t *testing.T) {
+	syslogtesting.Conformance(t, "testdata/conformance", *update, func(options []string) (syslog.Machine, error) {
+		opts := []syslog.MachineOption{}
+		for _, o := range options {
+			switch {
+			case o == "best-effort":
+				opts = append(opts, WithBestEffort())
+			case o == "rfc3339":
+				opts = append(opts, WithRFC3339())
+			case strings.HasPrefix(o, "year="):
+				yyyy, err := strconv.Atoi(strings.TrimPrefix(o, "year="))
+				if err != nil {
+					return nil, fmt.Errorf("option %q: %v", o, err)
+				}
+				opts = append(opts, WithYear(Year{YYYY: yyyy}))
+			default:
+				return nil, fmt.Errorf("unknown option %q", o)
+			}
+		}
+
+		return NewMachine(opts...), nil
+	})
+}
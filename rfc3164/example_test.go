@@ -31,7 +31,13 @@ func Example() {
 	//   ProcID: (*string)(<nil>),
 	//   MsgID: (*string)(<nil>),
 	//   Message: (*string)((len=4) "Test")
-	//  }
+	//  },
+	//  Msg: (*string)((len=9) "app: Test"),
+	//  Tag: (*string)((len=3) "app"),
+	//  Content: (*string)(<nil>),
+	//  PID: (*int)(<nil>),
+	//  Separator: (*string)((len=2) ": "),
+	//  Raw: (*rfc3164.Raw)(<nil>)
 	// })
 }
 
@@ -52,7 +58,13 @@ func Example_currentyear() {
 	//   ProcID: (*string)(<nil>),
 	//   MsgID: (*string)(<nil>),
 	//   Message: (*string)((len=4) "Test")
-	//  }
+	//  },
+	//  Msg: (*string)((len=9) "app: Test"),
+	//  Tag: (*string)((len=3) "app"),
+	//  Content: (*string)(<nil>),
+	//  PID: (*int)(<nil>),
+	//  Separator: (*string)((len=2) ": "),
+	//  Raw: (*rfc3164.Raw)(<nil>)
 	// })
 }
 
@@ -74,7 +86,13 @@ func Example_withtimezone() {
 	//   ProcID: (*string)(<nil>),
 	//   MsgID: (*string)(<nil>),
 	//   Message: (*string)((len=4) "Test")
-	//  }
+	//  },
+	//  Msg: (*string)((len=9) "app: Test"),
+	//  Tag: (*string)((len=3) "app"),
+	//  Content: (*string)(<nil>),
+	//  PID: (*int)(<nil>),
+	//  Separator: (*string)((len=2) ": "),
+	//  Raw: (*rfc3164.Raw)(<nil>)
 	// })
 }
 
@@ -96,7 +114,13 @@ func Example_withlocaletimezone() {
 	//   ProcID: (*string)(<nil>),
 	//   MsgID: (*string)(<nil>),
 	//   Message: (*string)((len=95) "[118479565.921459] EXT4-fs warning (device sda8): ext4_dx_add_entry:2006: Directory index full!")
-	//  }
+	//  },
+	//  Msg: (*string)((len=103) "kernel: [118479565.921459] EXT4-fs warning (device sda8): ext4_dx_add_entry:2006: Directory index full!"),
+	//  Tag: (*string)((len=6) "kernel"),
+	//  Content: (*string)(<nil>),
+	//  PID: (*int)(<nil>),
+	//  Separator: (*string)((len=2) ": "),
+	//  Raw: (*rfc3164.Raw)(<nil>)
 	// })
 }
 
@@ -118,7 +142,13 @@ func Example_withtimezone_and_year() {
 	//   ProcID: (*string)(<nil>),
 	//   MsgID: (*string)(<nil>),
 	//   Message: (*string)((len=4) "Test")
-	//  }
+	//  },
+	//  Msg: (*string)((len=9) "app: Test"),
+	//  Tag: (*string)((len=3) "app"),
+	//  Content: (*string)(<nil>),
+	//  PID: (*int)(<nil>),
+	//  Separator: (*string)((len=2) ": "),
+	//  Raw: (*rfc3164.Raw)(<nil>)
 	// })
 }
 
@@ -139,7 +169,13 @@ func Example_besteffort() {
 	//   ProcID: (*string)(<nil>),
 	//   MsgID: (*string)(<nil>),
 	//   Message: (*string)(<nil>)
-	//  }
+	//  },
+	//  Msg: (*string)(<nil>),
+	//  Tag: (*string)(<nil>),
+	//  Content: (*string)(<nil>),
+	//  PID: (*int)(<nil>),
+	//  Separator: (*string)(<nil>),
+	//  Raw: (*rfc3164.Raw)(<nil>)
 	// })
 }
 
@@ -160,7 +196,13 @@ func Example_rfc3339timestamp() {
 	//   ProcID: (*string)((len=5) "23410"),
 	//   MsgID: (*string)(<nil>),
 	//   Message: (*string)((len=4) "Test")
-	//  }
+	//  },
+	//  Msg: (*string)((len=16) "app[23410]: Test"),
+	//  Tag: (*string)((len=3) "app"),
+	//  Content: (*string)((len=5) "23410"),
+	//  PID: (*int)(23410),
+	//  Separator: (*string)((len=2) ": "),
+	//  Raw: (*rfc3164.Raw)(<nil>)
 	// })
 }
 
@@ -181,6 +223,12 @@ func Example_stamp_also_when_rfc3339() {
 	//   ProcID: (*string)((len=5) "23410"),
 	//   MsgID: (*string)(<nil>),
 	//   Message: (*string)((len=4) "Test")
-	//  }
+	//  },
+	//  Msg: (*string)((len=16) "app[23410]: Test"),
+	//  Tag: (*string)((len=3) "app"),
+	//  Content: (*string)((len=5) "23410"),
+	//  PID: (*int)(23410),
+	//  Separator: (*string)((len=2) ": "),
+	//  Raw: (*rfc3164.Raw)(<nil>)
 	// })
 }
@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+package rfc3164
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+var fuzzOptions = [][]syslog.MachineOption{
+	{WithYear(Year{YYYY: 2021})},
+	{WithBestEffort(), WithYear(Year{YYYY: 2021}), WithRaw()},
+	{WithBestEffort(), WithRFC3339(), WithTimezone(time.UTC), WithRaw()},
+	{WithRFC3339(), WithLocaleTimezone(time.FixedZone("", -7*3600))},
+}
+
+func FuzzParse(f *testing.F) {
+	for _, tc := range testCases {
+		f.Add(tc.input)
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		for _, opts := range fuzzOptions {
+			m, err := NewMachine(opts...).Parse(input)
+			if m == nil {
+				if err == nil {
+					t.Fatal("neither a message nor an error")
+				}
+				continue
+			}
+			if err == nil && !m.Valid() {
+				t.Fatalf("invalid message parsed without errors from %q", input)
+			}
+			raw := m.(*SyslogMessage).Raw
+			if raw == nil {
+				continue
+			}
+			for part, s := range map[string]syslog.Span{
+				"priority": raw.Priority, "timestamp": raw.Timestamp, "hostname": raw.Hostname, "msg": raw.Msg,
+				"tag": raw.Tag, "content": raw.Content, "separator": raw.Separator, "message": raw.Message,
+			} {
+				if s.Start < 0 || s.End < s.Start || s.End > len(raw.Input) {
+					t.Fatalf("%s span %v out of the input %q", part, s, raw.Input)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package rfc3164
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+type jsonMessage struct {
+	Format string `json:"format"`
+	syslog.BaseJSON
+	Msg       *string `json:"msg,omitempty"`
+	Tag       *string `json:"tag,omitempty"`
+	Content   *string `json:"content,omitempty"`
+	PID       *int    `json:"pid,omitempty"`
+	Separator *string `json:"separator,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The resulting object contains the "format" key (always "rfc3164"), the keys of syslog.BaseJSON,
+// the "msg" key with the whole MSG part, and the "tag", "content", "pid", and "separator" keys derived from it.
+// The Raw field is not part of it.
+func (m *SyslogMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMessage{
+		Format:    Format,
+		BaseJSON:  m.Base.JSON(),
+		Msg:       m.Msg,
+		Tag:       m.Tag,
+		Content:   m.Content,
+		PID:       m.PID,
+		Separator: m.Separator,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It accepts the objects produced by MarshalJSON.
+// The "format" key can be omitted, otherwise it must be "rfc3164".
+// When the "msg" key is present the fields derived from it are computed again,
+// ignoring the "tag", "content", "pid", "separator", "appname", "procid", and "message" keys.
+func (m *SyslogMessage) UnmarshalJSON(data []byte) error {
+	var j jsonMessage
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Format != "" && j.Format != Format {
+		return fmt.Errorf("expecting format %q, got %q", Format, j.Format)
+	}
+
+	*m = SyslogMessage{}
+	if err := m.Base.FromJSON(j.BaseJSON); err != nil {
+		return err
+	}
+	if j.Msg == nil {
+		m.Tag = j.Tag
+		m.Content = j.Content
+		m.PID = j.PID
+		m.Separator = j.Separator
+		return nil
+	}
+	m.SetMsg(*j.Msg)
+
+	return nil
+}
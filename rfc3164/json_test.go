@@ -0,0 +1,78 @@
+package rfc3164
+
+import (
+	"encoding/json"
+	"testing"
+
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	m, err := NewMachine(WithYear(Year{YYYY: 2019})).Parse([]byte("<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8"))
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"format": "rfc3164",
+		"priority": 34,
+		"facility": 4,
+		"facility_keyword": "auth",
+		"severity": 2,
+		"severity_keyword": "crit",
+		"timestamp": "2019-10-11T22:14:15Z",
+		"hostname": "mymachine",
+		"appname": "su",
+		"procid": "123",
+		"message": "'su root' failed for lonvick on /dev/pts/8",
+		"msg": "su[123]: 'su root' failed for lonvick on /dev/pts/8",
+		"tag": "su",
+		"content": "123",
+		"pid": 123,
+		"separator": ": "
+	}`, string(data))
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	cases := []string{
+		"<0>Dec  2 16:31:03 host x",
+		"<13>Dec  2 16:31:03 host sshd[1234]: Accepted publickey",
+		"<13>Dec  2 16:31:03.123456 host kernel: Started",
+		"<191>Dec  2 16:31:03 host no tag here",
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := NewMachine(WithYear(Year{YYYY: 2019})).Parse([]byte(tc))
+			assert.Nil(t, err)
+
+			data, err := json.Marshal(m)
+			assert.Nil(t, err)
+
+			got := &SyslogMessage{}
+			assert.Nil(t, json.Unmarshal(data, got))
+			assert.Equal(t, m, got)
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	got := &SyslogMessage{}
+
+	// The parts are derived from the MSG, when present
+	assert.Nil(t, json.Unmarshal([]byte(`{"priority": 13, "msg": "app: hello", "tag": "other", "message": "other"}`), got))
+	assert.Equal(t, syslogtesting.StringAddress("app"), got.Tag)
+	assert.Equal(t, syslogtesting.StringAddress("app"), got.Appname)
+	assert.Equal(t, syslogtesting.StringAddress("hello"), got.Message)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"priority": 13, "tag": "app", "message": "hello"}`), got))
+	assert.Nil(t, got.Msg)
+	assert.Equal(t, syslogtesting.StringAddress("app"), got.Tag)
+	assert.Equal(t, syslogtesting.StringAddress("hello"), got.Message)
+
+	assert.EqualError(t, json.Unmarshal([]byte(`{"format": "rfc5424", "priority": 1}`), got), `expecting format "rfc3164", got "rfc5424"`)
+}
@@ -9,21 +9,22 @@ import (
 )
 
 var (
-	errPrival       = "expecting a priority value in the range 1-191 or equal to 0 [col %d]"
-	errPri          = "expecting a priority value within angle brackets [col %d]"
-	errTimestamp    = "expecting a Stamp timestamp [col %d]"
-	errRFC3339      = "expecting a Stamp or a RFC3339 timestamp [col %d]"
-	errHostname     = "expecting an hostname (from 1 to max 255 US-ASCII characters) [col %d]"
-	errTag          = "expecting an alphanumeric tag (max 32 characters) [col %d]"
-	errContentStart = "expecting a content part starting with a non-alphanumeric character [col %d]"
-	errContent      = "expecting a content part composed by visible characters only [col %d]"
-	errParse        = "parsing error [col %d]"
+	errPrival    = "expecting a priority value in the range 1-191 or equal to 0 [col %d]"
+	errPri       = "expecting a priority value within angle brackets [col %d]"
+	errTimestamp = "expecting a Stamp timestamp [col %d]"
+	errRFC3339   = "expecting a Stamp or a RFC3339 timestamp [col %d]"
+	errHostname  = "expecting an hostname (from 1 to max 255 US-ASCII characters) [col %d]"
+	errMsg       = "expecting a message composed by visible characters only [col %d]"
+	errParse     = "parsing error [col %d]"
 )
 
+// stampWithYear is the Stamp layout having a 4-digit year before the time, as sent by some network devices.
+const stampWithYear = "Jan _2 2006 15:04:05"
+
 const start int = 1
-const firstFinal int = 333
+const firstFinal int = 347
 
-const enFail int = 373
+const enFail int = 348
 const enMain int = 1
 
 type machine struct {
@@ -37,6 +38,7 @@ type machine struct {
 	rfc3339    bool
 	loc        *time.Location
 	timezone   *time.Location
+	raw        bool
 }
 
 // NewMachine creates a new FSM able to parse RFC3164 syslog messages.
@@ -82,6 +84,11 @@ func (m *machine) WithRFC3339() {
 	m.rfc3339 = true
 }
 
+// WithRaw enables the recording of the raw input and of the positions of the parts of the messages.
+func (m *machine) WithRaw() {
+	m.raw = true
+}
+
 // Err returns the error that occurred on the last call to Parse.
 //
 // If the result is nil, then the line was parsed successfully.
@@ -93,6 +100,30 @@ func (m *machine) text() []byte {
 	return m.data[m.pb:m.p]
 }
 
+func (m *machine) span() syslog.Span {
+	return syslog.Span{Start: m.pb, End: m.p}
+}
+
+// parseStamp parses a Stamp timestamp, also when it has fractional seconds or a 4-digit year after the day.
+//
+// The year strategy applies only to timestamps without their own year.
+func (m *machine) parseStamp(ts []byte) (time.Time, error) {
+	loc := time.UTC
+	if m.timezone != nil {
+		loc = m.timezone
+	}
+	// Mmm dd yyyy hh:mm:ss
+	if len(ts) > 9 && ts[9] != ':' {
+		return time.ParseInLocation(stampWithYear, string(ts), loc)
+	}
+	t, err := time.ParseInLocation(time.Stamp, string(ts), loc)
+	if err != nil {
+		return t, err
+	}
+
+	return t.AddDate(m.yyyy, 0, 0), nil
+}
+
 // Parse parses the input byte array as a RFC3164 syslog message.
 func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	m.data = input
@@ -102,6 +133,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	m.eof = len(input)
 	m.err = nil
 	output := &syslogMessage{}
+	if m.raw {
+		output.raw = &Raw{Input: append([]byte(nil), input...)}
+	}
 
 	{
 		m.cs = start
@@ -159,78 +193,8 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto stCase21
 		case 22:
 			goto stCase22
-		case 333:
-			goto stCase333
-		case 334:
-			goto stCase334
-		case 335:
-			goto stCase335
-		case 336:
-			goto stCase336
-		case 337:
-			goto stCase337
-		case 338:
-			goto stCase338
-		case 339:
-			goto stCase339
-		case 340:
-			goto stCase340
-		case 341:
-			goto stCase341
-		case 342:
-			goto stCase342
-		case 343:
-			goto stCase343
-		case 344:
-			goto stCase344
-		case 345:
-			goto stCase345
-		case 346:
-			goto stCase346
 		case 347:
 			goto stCase347
-		case 348:
-			goto stCase348
-		case 349:
-			goto stCase349
-		case 350:
-			goto stCase350
-		case 351:
-			goto stCase351
-		case 352:
-			goto stCase352
-		case 353:
-			goto stCase353
-		case 354:
-			goto stCase354
-		case 355:
-			goto stCase355
-		case 356:
-			goto stCase356
-		case 357:
-			goto stCase357
-		case 358:
-			goto stCase358
-		case 359:
-			goto stCase359
-		case 360:
-			goto stCase360
-		case 361:
-			goto stCase361
-		case 362:
-			goto stCase362
-		case 363:
-			goto stCase363
-		case 364:
-			goto stCase364
-		case 365:
-			goto stCase365
-		case 366:
-			goto stCase366
-		case 367:
-			goto stCase367
-		case 368:
-			goto stCase368
 		case 23:
 			goto stCase23
 		case 24:
@@ -239,14 +203,6 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto stCase25
 		case 26:
 			goto stCase26
-		case 369:
-			goto stCase369
-		case 370:
-			goto stCase370
-		case 371:
-			goto stCase371
-		case 372:
-			goto stCase372
 		case 27:
 			goto stCase27
 		case 28:
@@ -859,8 +815,36 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto stCase331
 		case 332:
 			goto stCase332
-		case 373:
-			goto stCase373
+		case 333:
+			goto stCase333
+		case 334:
+			goto stCase334
+		case 335:
+			goto stCase335
+		case 336:
+			goto stCase336
+		case 337:
+			goto stCase337
+		case 338:
+			goto stCase338
+		case 339:
+			goto stCase339
+		case 340:
+			goto stCase340
+		case 341:
+			goto stCase341
+		case 342:
+			goto stCase342
+		case 343:
+			goto stCase343
+		case 344:
+			goto stCase344
+		case 345:
+			goto stCase345
+		case 346:
+			goto stCase346
+		case 348:
+			goto stCase348
 		}
 		goto stOut
 	stCase1:
@@ -874,7 +858,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		(m.p)--
 
 		{
-			goto st373
+			goto st348
 		}
 
 		goto st0
@@ -884,14 +868,14 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		(m.p)--
 
 		{
-			goto st373
+			goto st348
 		}
 
 		m.err = fmt.Errorf(errPri, m.p)
 		(m.p)--
 
 		{
-			goto st373
+			goto st348
 		}
 
 		goto st0
@@ -901,7 +885,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		(m.p)--
 
 		{
-			goto st373
+			goto st348
 		}
 
 		goto st0
@@ -911,17 +895,17 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		(m.p)--
 
 		{
-			goto st373
+			goto st348
 		}
 
 		goto st0
 	tr41:
 
-		m.err = fmt.Errorf(errTag, m.p)
+		m.err = fmt.Errorf(errMsg, m.p)
 		(m.p)--
 
 		{
-			goto st373
+			goto st348
 		}
 
 		goto st0
@@ -931,7 +915,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		(m.p)--
 
 		{
-			goto st373
+			goto st348
 		}
 
 		goto st0
@@ -967,6 +951,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 
 		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
 		output.prioritySet = true
+		if output.raw != nil {
+			output.raw.Priority = m.span()
+		}
 
 		if (m.data)[(m.p)] == 62 {
 			goto st4
@@ -1020,7 +1007,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		case 112:
 			goto st6
 		case 117:
-			goto st284
+			goto st280
 		}
 		goto tr7
 	st6:
@@ -1050,10 +1037,10 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		case 32:
 			goto st9
 		case 51:
-			goto st283
+			goto st279
 		}
 		if 49 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 50 {
-			goto st282
+			goto st278
 		}
 		goto tr7
 	st9:
@@ -1079,10 +1066,15 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof11
 		}
 	stCase11:
-		if (m.data)[(m.p)] == 50 {
-			goto st281
-		}
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
+		switch {
+		case (m.data)[(m.p)] > 50:
+			if (m.data)[(m.p)] <= 57 {
+				goto st329
+			}
+		case (m.data)[(m.p)] >= 48:
+			if (m.data)[(m.p)] == 50 {
+				goto st277
+			}
 			goto st12
 		}
 		goto tr7
@@ -1103,6 +1095,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		if (m.data)[(m.p)] == 58 {
 			goto st14
 		}
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st331
+		}
 		goto tr7
 	st14:
 		if (m.p)++; (m.p) == (m.pe) {
@@ -1154,28 +1149,31 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof19
 		}
 	stCase19:
-		if (m.data)[(m.p)] == 32 {
+		switch (m.data)[(m.p)] {
+		case 32:
 			goto tr35
+		case 46:
+			goto st337
 		}
 		goto st0
 	tr35:
 
-		if t, e := time.Parse(time.Stamp, string(m.text())); e != nil {
+		if t, e := m.parseStamp(m.text()); e != nil {
 			m.err = fmt.Errorf("%s [col %d]", e, m.p)
 			(m.p)--
 
 			{
-				goto st373
+				goto st348
 			}
 		} else {
-			if m.timezone != nil {
-				t, _ = time.ParseInLocation(time.Stamp, string(m.text()), m.timezone)
-			}
-			output.timestamp = t.AddDate(m.yyyy, 0, 0)
+			output.timestamp = t
 			if m.loc != nil {
 				output.timestamp = output.timestamp.In(m.loc)
 			}
 			output.timestampSet = true
+			if output.raw != nil {
+				output.raw.Timestamp = m.span()
+			}
 		}
 
 		goto st20
@@ -1186,11 +1184,14 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			(m.p)--
 
 			{
-				goto st373
+				goto st348
 			}
 		} else {
 			output.timestamp = t
 			output.timestampSet = true
+			if output.raw != nil {
+				output.raw.Timestamp = m.span()
+			}
 		}
 
 		goto st20
@@ -1217,12 +1218,15 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto tr39
 		}
 		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-			goto st27
+			goto st23
 		}
 		goto tr37
 	tr39:
 
 		output.hostname = string(m.text())
+		if output.raw != nil {
+			output.raw.Hostname = m.span()
+		}
 
 		goto st22
 	st22:
@@ -1230,965 +1234,109 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof22
 		}
 	stCase22:
-		if (m.data)[(m.p)] == 127 {
-			goto tr41
-		}
 		switch {
-		case (m.data)[(m.p)] < 33:
-			if (m.data)[(m.p)] <= 31 {
+		case (m.data)[(m.p)] > 31:
+			if (m.data)[(m.p)] == 127 {
 				goto tr41
 			}
-		case (m.data)[(m.p)] > 57:
-			switch {
-			case (m.data)[(m.p)] > 90:
-				if 92 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-					goto tr43
-				}
-			case (m.data)[(m.p)] >= 59:
-				goto tr43
-			}
+		case (m.data)[(m.p)] >= 10:
+			goto tr41
 		default:
-			goto tr43
+			if (m.data)[(m.p)] <= 8 {
+				goto tr41
+			}
 		}
 		goto tr42
 	tr42:
 
 		m.pb = m.p
 
-		goto st333
-	st333:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof333
-		}
-	stCase333:
-		if (m.data)[(m.p)] == 127 {
-			goto st0
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto st0
-		}
-		goto st333
-	tr43:
-
-		m.pb = m.p
-
-		goto st334
-	st334:
+		goto st347
+	st347:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof334
-		}
-	stCase334:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
+			goto _testEof347
 		}
+	stCase347:
 		switch {
 		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st335
+			if (m.data)[(m.p)] == 127 {
+				goto st0
 			}
-		default:
+		case (m.data)[(m.p)] >= 10:
 			goto st0
+		default:
+			if (m.data)[(m.p)] <= 8 {
+				goto st0
+			}
 		}
-		goto st333
-	st335:
+		goto st347
+	st23:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof335
+			goto _testEof23
 		}
-	stCase335:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
+	stCase23:
+		if (m.data)[(m.p)] == 32 {
+			goto tr39
 		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st336
-			}
-		default:
-			goto st0
+		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
+			goto st24
 		}
-		goto st333
-	st336:
+		goto tr37
+	st24:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof336
+			goto _testEof24
 		}
-	stCase336:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
+	stCase24:
+		if (m.data)[(m.p)] == 32 {
+			goto tr39
 		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st337
-			}
-		default:
-			goto st0
+		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
+			goto st25
 		}
-		goto st333
-	st337:
+		goto tr37
+	st25:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof337
+			goto _testEof25
 		}
-	stCase337:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
+	stCase25:
+		if (m.data)[(m.p)] == 32 {
+			goto tr39
 		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st338
-			}
-		default:
-			goto st0
+		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
+			goto st26
 		}
-		goto st333
-	st338:
+		goto tr37
+	st26:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof338
+			goto _testEof26
 		}
-	stCase338:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
+	stCase26:
+		if (m.data)[(m.p)] == 32 {
+			goto tr39
 		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st339
-			}
-		default:
-			goto st0
+		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
+			goto st27
 		}
-		goto st333
-	st339:
+		goto tr37
+	st27:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof339
+			goto _testEof27
 		}
-	stCase339:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
+	stCase27:
+		if (m.data)[(m.p)] == 32 {
+			goto tr39
 		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st340
-			}
-		default:
-			goto st0
+		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
+			goto st28
 		}
-		goto st333
-	st340:
+		goto tr37
+	st28:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof340
+			goto _testEof28
 		}
-	stCase340:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st341
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st341:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof341
-		}
-	stCase341:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st342
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st342:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof342
-		}
-	stCase342:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st343
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st343:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof343
-		}
-	stCase343:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st344
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st344:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof344
-		}
-	stCase344:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st345
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st345:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof345
-		}
-	stCase345:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st346
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st346:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof346
-		}
-	stCase346:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st347
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st347:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof347
-		}
-	stCase347:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st348
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st348:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof348
-		}
-	stCase348:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st349
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st349:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof349
-		}
-	stCase349:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st350
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st350:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof350
-		}
-	stCase350:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st351
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st351:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof351
-		}
-	stCase351:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st352
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st352:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof352
-		}
-	stCase352:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st353
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st353:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof353
-		}
-	stCase353:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st354
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st354:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof354
-		}
-	stCase354:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st355
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st355:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof355
-		}
-	stCase355:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st356
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st356:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof356
-		}
-	stCase356:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st357
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st357:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof357
-		}
-	stCase357:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st358
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st358:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof358
-		}
-	stCase358:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st359
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st359:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof359
-		}
-	stCase359:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st360
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st360:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof360
-		}
-	stCase360:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st361
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st361:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof361
-		}
-	stCase361:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st362
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st362:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof362
-		}
-	stCase362:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st363
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st363:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof363
-		}
-	stCase363:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st364
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st364:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof364
-		}
-	stCase364:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		switch {
-		case (m.data)[(m.p)] > 31:
-			if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-				goto st365
-			}
-		default:
-			goto st0
-		}
-		goto st333
-	st365:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof365
-		}
-	stCase365:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto tr347
-		case 91:
-			goto tr348
-		case 127:
-			goto st0
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto st0
-		}
-		goto st333
-	tr347:
-
-		output.tag = string(m.text())
-
-		goto st366
-	st366:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof366
-		}
-	stCase366:
-		switch (m.data)[(m.p)] {
-		case 32:
-			goto st367
-		case 127:
-			goto st0
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto st0
-		}
-		goto st333
-	st367:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof367
-		}
-	stCase367:
-		if (m.data)[(m.p)] == 127 {
-			goto st0
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto st0
-		}
-		goto tr42
-	tr348:
-
-		output.tag = string(m.text())
-
-		goto st368
-	st368:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof368
-		}
-	stCase368:
-		switch (m.data)[(m.p)] {
-		case 93:
-			goto tr381
-		case 127:
-			goto tr380
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto tr380
-		}
-		goto tr48
-	tr380:
-
-		m.pb = m.p
-
-		goto st23
-	st23:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof23
-		}
-	stCase23:
-		if (m.data)[(m.p)] == 93 {
-			goto tr45
-		}
-		goto st23
-	tr45:
-
-		output.content = string(m.text())
-
-		goto st24
-	st24:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof24
-		}
-	stCase24:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto st25
-		case 93:
-			goto tr45
-		}
-		goto st23
-	st25:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof25
-		}
-	stCase25:
-		switch (m.data)[(m.p)] {
-		case 32:
-			goto st26
-		case 93:
-			goto tr45
-		}
-		goto st23
-	st26:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof26
-		}
-	stCase26:
-		switch (m.data)[(m.p)] {
-		case 93:
-			goto tr49
-		case 127:
-			goto st23
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto st23
-		}
-		goto tr48
-	tr48:
-
-		m.pb = m.p
-
-		goto st369
-	st369:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof369
-		}
-	stCase369:
-		switch (m.data)[(m.p)] {
-		case 93:
-			goto tr383
-		case 127:
-			goto st23
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto st23
-		}
-		goto st369
-	tr383:
-
-		output.content = string(m.text())
-
-		goto st370
-	tr49:
-
-		output.content = string(m.text())
-
-		m.pb = m.p
-
-		goto st370
-	tr381:
-
-		m.pb = m.p
-
-		output.content = string(m.text())
-
-		goto st370
-	st370:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof370
-		}
-	stCase370:
-		switch (m.data)[(m.p)] {
-		case 58:
-			goto st371
-		case 93:
-			goto tr383
-		case 127:
-			goto st23
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto st23
-		}
-		goto st369
-	st371:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof371
-		}
-	stCase371:
-		switch (m.data)[(m.p)] {
-		case 32:
-			goto st372
-		case 93:
-			goto tr383
-		case 127:
-			goto st23
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto st23
-		}
-		goto st369
-	st372:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof372
-		}
-	stCase372:
-		switch (m.data)[(m.p)] {
-		case 93:
-			goto tr49
-		case 127:
-			goto st23
-		}
-		if (m.data)[(m.p)] <= 31 {
-			goto st23
-		}
-		goto tr48
-	st27:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof27
-		}
-	stCase27:
-		if (m.data)[(m.p)] == 32 {
-			goto tr39
-		}
-		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-			goto st28
-		}
-		goto tr37
-	st28:
-		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof28
-		}
-	stCase28:
-		if (m.data)[(m.p)] == 32 {
-			goto tr39
+	stCase28:
+		if (m.data)[(m.p)] == 32 {
+			goto tr39
 		}
 		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
 			goto st29
@@ -5166,62 +4314,60 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		if (m.data)[(m.p)] == 32 {
 			goto tr39
 		}
-		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-			goto st277
-		}
 		goto tr37
 	st277:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof277
 		}
 	stCase277:
-		if (m.data)[(m.p)] == 32 {
-			goto tr39
-		}
-		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-			goto st278
+		switch {
+		case (m.data)[(m.p)] > 51:
+			if (m.data)[(m.p)] <= 57 {
+				goto st330
+			}
+		case (m.data)[(m.p)] >= 48:
+			goto st13
 		}
-		goto tr37
+		goto tr7
 	st278:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof278
 		}
 	stCase278:
-		if (m.data)[(m.p)] == 32 {
-			goto tr39
-		}
-		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-			goto st279
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st10
 		}
-		goto tr37
+		goto tr7
 	st279:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof279
 		}
 	stCase279:
-		if (m.data)[(m.p)] == 32 {
-			goto tr39
-		}
-		if 33 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 126 {
-			goto st280
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
+			goto st10
 		}
-		goto tr37
+		goto tr7
 	st280:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof280
 		}
 	stCase280:
-		if (m.data)[(m.p)] == 32 {
-			goto tr39
+		if (m.data)[(m.p)] == 103 {
+			goto st7
 		}
-		goto tr37
+		goto tr7
+	tr9:
+
+		m.pb = m.p
+
+		goto st281
 	st281:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof281
 		}
 	stCase281:
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 51 {
-			goto st13
+		if (m.data)[(m.p)] == 101 {
+			goto st282
 		}
 		goto tr7
 	st282:
@@ -5229,17 +4375,22 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof282
 		}
 	stCase282:
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
-			goto st10
+		if (m.data)[(m.p)] == 99 {
+			goto st7
 		}
 		goto tr7
+	tr10:
+
+		m.pb = m.p
+
+		goto st283
 	st283:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof283
 		}
 	stCase283:
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
-			goto st10
+		if (m.data)[(m.p)] == 101 {
+			goto st284
 		}
 		goto tr7
 	st284:
@@ -5247,11 +4398,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof284
 		}
 	stCase284:
-		if (m.data)[(m.p)] == 103 {
+		if (m.data)[(m.p)] == 98 {
 			goto st7
 		}
 		goto tr7
-	tr9:
+	tr11:
 
 		m.pb = m.p
 
@@ -5261,8 +4412,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof285
 		}
 	stCase285:
-		if (m.data)[(m.p)] == 101 {
+		switch (m.data)[(m.p)] {
+		case 97:
 			goto st286
+		case 117:
+			goto st287
 		}
 		goto tr7
 	st286:
@@ -5270,57 +4424,60 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof286
 		}
 	stCase286:
-		if (m.data)[(m.p)] == 99 {
+		if (m.data)[(m.p)] == 110 {
 			goto st7
 		}
 		goto tr7
-	tr10:
-
-		m.pb = m.p
-
-		goto st287
 	st287:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof287
 		}
 	stCase287:
-		if (m.data)[(m.p)] == 101 {
-			goto st288
+		switch (m.data)[(m.p)] {
+		case 108:
+			goto st7
+		case 110:
+			goto st7
 		}
 		goto tr7
+	tr12:
+
+		m.pb = m.p
+
+		goto st288
 	st288:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof288
 		}
 	stCase288:
-		if (m.data)[(m.p)] == 98 {
-			goto st7
+		if (m.data)[(m.p)] == 97 {
+			goto st289
 		}
 		goto tr7
-	tr11:
-
-		m.pb = m.p
-
-		goto st289
 	st289:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof289
 		}
 	stCase289:
 		switch (m.data)[(m.p)] {
-		case 97:
-			goto st290
-		case 117:
-			goto st291
+		case 114:
+			goto st7
+		case 121:
+			goto st7
 		}
 		goto tr7
+	tr13:
+
+		m.pb = m.p
+
+		goto st290
 	st290:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof290
 		}
 	stCase290:
-		if (m.data)[(m.p)] == 110 {
-			goto st7
+		if (m.data)[(m.p)] == 111 {
+			goto st291
 		}
 		goto tr7
 	st291:
@@ -5328,14 +4485,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof291
 		}
 	stCase291:
-		switch (m.data)[(m.p)] {
-		case 108:
-			goto st7
-		case 110:
+		if (m.data)[(m.p)] == 118 {
 			goto st7
 		}
 		goto tr7
-	tr12:
+	tr14:
 
 		m.pb = m.p
 
@@ -5345,7 +4499,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof292
 		}
 	stCase292:
-		if (m.data)[(m.p)] == 97 {
+		if (m.data)[(m.p)] == 99 {
 			goto st293
 		}
 		goto tr7
@@ -5354,14 +4508,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof293
 		}
 	stCase293:
-		switch (m.data)[(m.p)] {
-		case 114:
-			goto st7
-		case 121:
+		if (m.data)[(m.p)] == 116 {
 			goto st7
 		}
 		goto tr7
-	tr13:
+	tr15:
 
 		m.pb = m.p
 
@@ -5371,7 +4522,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof294
 		}
 	stCase294:
-		if (m.data)[(m.p)] == 111 {
+		if (m.data)[(m.p)] == 101 {
 			goto st295
 		}
 		goto tr7
@@ -5380,11 +4531,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof295
 		}
 	stCase295:
-		if (m.data)[(m.p)] == 118 {
+		if (m.data)[(m.p)] == 112 {
 			goto st7
 		}
 		goto tr7
-	tr14:
+	tr16:
 
 		m.pb = m.p
 
@@ -5394,61 +4545,90 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof296
 		}
 	stCase296:
-		if (m.data)[(m.p)] == 99 {
+		_widec = int16((m.data)[(m.p)])
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+			if m.rfc3339 {
+				_widec += 256
+			}
+		}
+		if 560 <= _widec && _widec <= 569 {
 			goto st297
 		}
-		goto tr7
+		goto st0
 	st297:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof297
 		}
 	stCase297:
-		if (m.data)[(m.p)] == 116 {
-			goto st7
+		_widec = int16((m.data)[(m.p)])
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+			if m.rfc3339 {
+				_widec += 256
+			}
 		}
-		goto tr7
-	tr15:
-
-		m.pb = m.p
-
-		goto st298
+		if 560 <= _widec && _widec <= 569 {
+			goto st298
+		}
+		goto st0
 	st298:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof298
 		}
 	stCase298:
-		if (m.data)[(m.p)] == 101 {
+		_widec = int16((m.data)[(m.p)])
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+			if m.rfc3339 {
+				_widec += 256
+			}
+		}
+		if 560 <= _widec && _widec <= 569 {
 			goto st299
 		}
-		goto tr7
+		goto st0
 	st299:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof299
 		}
 	stCase299:
-		if (m.data)[(m.p)] == 112 {
-			goto st7
+		_widec = int16((m.data)[(m.p)])
+		if 45 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 45 {
+			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+			if m.rfc3339 {
+				_widec += 256
+			}
 		}
-		goto tr7
-	tr16:
-
-		m.pb = m.p
-
-		goto st300
+		if _widec == 557 {
+			goto st300
+		}
+		goto st0
 	st300:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof300
 		}
 	stCase300:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+		switch {
+		case (m.data)[(m.p)] > 48:
+			if 49 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
+				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+				if m.rfc3339 {
+					_widec += 256
+				}
+			}
+		case (m.data)[(m.p)] >= 48:
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 569 {
+		switch _widec {
+		case 560:
 			goto st301
+		case 561:
+			goto st325
 		}
 		goto st0
 	st301:
@@ -5457,13 +4637,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase301:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+		if 49 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 569 {
+		if 561 <= _widec && _widec <= 569 {
 			goto st302
 		}
 		goto st0
@@ -5473,13 +4653,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase302:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+		if 45 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 45 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 569 {
+		if _widec == 557 {
 			goto st303
 		}
 		goto st0
@@ -5489,14 +4669,35 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase303:
 		_widec = int16((m.data)[(m.p)])
-		if 45 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 45 {
+		switch {
+		case (m.data)[(m.p)] < 49:
+			if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 48 {
+				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+				if m.rfc3339 {
+					_widec += 256
+				}
+			}
+		case (m.data)[(m.p)] > 50:
+			if 51 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 51 {
+				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+				if m.rfc3339 {
+					_widec += 256
+				}
+			}
+		default:
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if _widec == 557 {
+		switch _widec {
+		case 560:
 			goto st304
+		case 563:
+			goto st324
+		}
+		if 561 <= _widec && _widec <= 562 {
+			goto st323
 		}
 		goto st0
 	st304:
@@ -5505,25 +4706,14 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase304:
 		_widec = int16((m.data)[(m.p)])
-		switch {
-		case (m.data)[(m.p)] > 48:
-			if 49 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
-				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-				if m.rfc3339 {
-					_widec += 256
-				}
-			}
-		case (m.data)[(m.p)] >= 48:
+		if 49 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		switch _widec {
-		case 560:
+		if 561 <= _widec && _widec <= 569 {
 			goto st305
-		case 561:
-			goto st329
 		}
 		goto st0
 	st305:
@@ -5532,13 +4722,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase305:
 		_widec = int16((m.data)[(m.p)])
-		if 49 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+		if 84 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 84 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 561 <= _widec && _widec <= 569 {
+		if _widec == 596 {
 			goto st306
 		}
 		goto st0
@@ -5548,13 +4738,24 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase306:
 		_widec = int16((m.data)[(m.p)])
-		if 45 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 45 {
+		switch {
+		case (m.data)[(m.p)] > 49:
+			if 50 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 50 {
+				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+				if m.rfc3339 {
+					_widec += 256
+				}
+			}
+		case (m.data)[(m.p)] >= 48:
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if _widec == 557 {
+		if _widec == 562 {
+			goto st322
+		}
+		if 560 <= _widec && _widec <= 561 {
 			goto st307
 		}
 		goto st0
@@ -5564,35 +4765,14 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase307:
 		_widec = int16((m.data)[(m.p)])
-		switch {
-		case (m.data)[(m.p)] < 49:
-			if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 48 {
-				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-				if m.rfc3339 {
-					_widec += 256
-				}
-			}
-		case (m.data)[(m.p)] > 50:
-			if 51 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 51 {
-				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-				if m.rfc3339 {
-					_widec += 256
-				}
-			}
-		default:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		switch _widec {
-		case 560:
+		if 560 <= _widec && _widec <= 569 {
 			goto st308
-		case 563:
-			goto st328
-		}
-		if 561 <= _widec && _widec <= 562 {
-			goto st327
 		}
 		goto st0
 	st308:
@@ -5601,13 +4781,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase308:
 		_widec = int16((m.data)[(m.p)])
-		if 49 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+		if 58 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 58 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 561 <= _widec && _widec <= 569 {
+		if _widec == 570 {
 			goto st309
 		}
 		goto st0
@@ -5617,13 +4797,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase309:
 		_widec = int16((m.data)[(m.p)])
-		if 84 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 84 {
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 53 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if _widec == 596 {
+		if 560 <= _widec && _widec <= 565 {
 			goto st310
 		}
 		goto st0
@@ -5633,24 +4813,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase310:
 		_widec = int16((m.data)[(m.p)])
-		switch {
-		case (m.data)[(m.p)] > 49:
-			if 50 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 50 {
-				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-				if m.rfc3339 {
-					_widec += 256
-				}
-			}
-		case (m.data)[(m.p)] >= 48:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if _widec == 562 {
-			goto st326
-		}
-		if 560 <= _widec && _widec <= 561 {
+		if 560 <= _widec && _widec <= 569 {
 			goto st311
 		}
 		goto st0
@@ -5660,13 +4829,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase311:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+		if 58 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 58 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 569 {
+		if _widec == 570 {
 			goto st312
 		}
 		goto st0
@@ -5676,13 +4845,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase312:
 		_widec = int16((m.data)[(m.p)])
-		if 58 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 58 {
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 53 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if _widec == 570 {
+		if 560 <= _widec && _widec <= 565 {
 			goto st313
 		}
 		goto st0
@@ -5692,13 +4861,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase313:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 53 {
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 565 {
+		if 560 <= _widec && _widec <= 569 {
 			goto st314
 		}
 		goto st0
@@ -5708,98 +4877,109 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase314:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+		switch {
+		case (m.data)[(m.p)] < 45:
+			if 43 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 43 {
+				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+				if m.rfc3339 {
+					_widec += 256
+				}
+			}
+		case (m.data)[(m.p)] > 45:
+			if 90 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 90 {
+				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+				if m.rfc3339 {
+					_widec += 256
+				}
+			}
+		default:
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 569 {
+		switch _widec {
+		case 555:
+			goto st315
+		case 557:
 			goto st315
+		case 602:
+			goto st320
 		}
-		goto st0
+		goto tr333
 	st315:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof315
 		}
 	stCase315:
 		_widec = int16((m.data)[(m.p)])
-		if 58 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 58 {
+		switch {
+		case (m.data)[(m.p)] > 49:
+			if 50 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 50 {
+				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+				if m.rfc3339 {
+					_widec += 256
+				}
+			}
+		case (m.data)[(m.p)] >= 48:
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if _widec == 570 {
+		if _widec == 562 {
+			goto st321
+		}
+		if 560 <= _widec && _widec <= 561 {
 			goto st316
 		}
-		goto st0
+		goto tr333
 	st316:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof316
 		}
 	stCase316:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 53 {
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 565 {
+		if 560 <= _widec && _widec <= 569 {
 			goto st317
 		}
-		goto st0
+		goto tr333
 	st317:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof317
 		}
 	stCase317:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+		if 58 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 58 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 569 {
+		if _widec == 570 {
 			goto st318
 		}
-		goto st0
+		goto tr333
 	st318:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof318
 		}
 	stCase318:
 		_widec = int16((m.data)[(m.p)])
-		switch {
-		case (m.data)[(m.p)] < 45:
-			if 43 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 43 {
-				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-				if m.rfc3339 {
-					_widec += 256
-				}
-			}
-		case (m.data)[(m.p)] > 45:
-			if 90 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 90 {
-				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-				if m.rfc3339 {
-					_widec += 256
-				}
-			}
-		default:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 53 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		switch _widec {
-		case 555:
-			goto st319
-		case 557:
+		if 560 <= _widec && _widec <= 565 {
 			goto st319
-		case 602:
-			goto st324
 		}
 		goto tr333
 	st319:
@@ -5808,24 +4988,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase319:
 		_widec = int16((m.data)[(m.p)])
-		switch {
-		case (m.data)[(m.p)] > 49:
-			if 50 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 50 {
-				_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-				if m.rfc3339 {
-					_widec += 256
-				}
-			}
-		case (m.data)[(m.p)] >= 48:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if _widec == 562 {
-			goto st325
-		}
-		if 560 <= _widec && _widec <= 561 {
+		if 560 <= _widec && _widec <= 569 {
 			goto st320
 		}
 		goto tr333
@@ -5834,31 +5003,24 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			goto _testEof320
 		}
 	stCase320:
-		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
-			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-			if m.rfc3339 {
-				_widec += 256
-			}
-		}
-		if 560 <= _widec && _widec <= 569 {
-			goto st321
+		if (m.data)[(m.p)] == 32 {
+			goto tr341
 		}
-		goto tr333
+		goto st0
 	st321:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof321
 		}
 	stCase321:
 		_widec = int16((m.data)[(m.p)])
-		if 58 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 58 {
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 51 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if _widec == 570 {
-			goto st322
+		if 560 <= _widec && _widec <= 563 {
+			goto st317
 		}
 		goto tr333
 	st322:
@@ -5867,16 +5029,16 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase322:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 53 {
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 51 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 565 {
-			goto st323
+		if 560 <= _widec && _widec <= 563 {
+			goto st308
 		}
-		goto tr333
+		goto st0
 	st323:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof323
@@ -5890,16 +5052,23 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			}
 		}
 		if 560 <= _widec && _widec <= 569 {
-			goto st324
+			goto st305
 		}
-		goto tr333
+		goto st0
 	st324:
 		if (m.p)++; (m.p) == (m.pe) {
 			goto _testEof324
 		}
 	stCase324:
-		if (m.data)[(m.p)] == 32 {
-			goto tr341
+		_widec = int16((m.data)[(m.p)])
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
+			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
+			if m.rfc3339 {
+				_widec += 256
+			}
+		}
+		if 560 <= _widec && _widec <= 561 {
+			goto st305
 		}
 		goto st0
 	st325:
@@ -5908,153 +5077,287 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	stCase325:
 		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 51 {
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 50 {
 			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
 			if m.rfc3339 {
 				_widec += 256
 			}
 		}
-		if 560 <= _widec && _widec <= 563 {
-			goto st321
+		if 560 <= _widec && _widec <= 562 {
+			goto st302
+		}
+		goto st0
+	tr4:
+
+		m.pb = m.p
+
+		goto st326
+	st326:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof326
+		}
+	stCase326:
+
+		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
+		output.prioritySet = true
+		if output.raw != nil {
+			output.raw.Priority = m.span()
+		}
+
+		switch (m.data)[(m.p)] {
+		case 57:
+			goto st328
+		case 62:
+			goto st4
+		}
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 56 {
+			goto st327
+		}
+		goto tr2
+	tr5:
+
+		m.pb = m.p
+
+		goto st327
+	st327:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof327
+		}
+	stCase327:
+
+		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
+		output.prioritySet = true
+		if output.raw != nil {
+			output.raw.Priority = m.span()
+		}
+
+		if (m.data)[(m.p)] == 62 {
+			goto st4
+		}
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st3
+		}
+		goto tr2
+	st328:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof328
+		}
+	stCase328:
+
+		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
+		output.prioritySet = true
+		if output.raw != nil {
+			output.raw.Priority = m.span()
+		}
+
+		if (m.data)[(m.p)] == 62 {
+			goto st4
+		}
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
+			goto st3
+		}
+		goto tr2
+	st329:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof329
+		}
+	stCase329:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st330
+		}
+		goto tr7
+	st330:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof330
+		}
+	stCase330:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st331
+		}
+		goto tr7
+	st331:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof331
+		}
+	stCase331:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st332
+		}
+		goto tr7
+	st332:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof332
+		}
+	stCase332:
+		if (m.data)[(m.p)] == 32 {
+			goto st333
+		}
+		goto tr7
+	st333:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof333
+		}
+	stCase333:
+		if (m.data)[(m.p)] == 50 {
+			goto st335
+		}
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
+			goto st334
+		}
+		goto tr7
+	st334:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof334
+		}
+	stCase334:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st336
+		}
+		goto tr7
+	st335:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof335
+		}
+	stCase335:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 51 {
+			goto st336
+		}
+		goto tr7
+	st336:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof336
+		}
+	stCase336:
+		if (m.data)[(m.p)] == 58 {
+			goto st14
+		}
+		goto tr7
+	st337:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof337
+		}
+	stCase337:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st338
+		}
+		goto tr7
+	st338:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof338
+		}
+	stCase338:
+		if (m.data)[(m.p)] == 32 {
+			goto tr35
+		}
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st339
+		}
+		goto st0
+	st339:
+		if (m.p)++; (m.p) == (m.pe) {
+			goto _testEof339
+		}
+	stCase339:
+		if (m.data)[(m.p)] == 32 {
+			goto tr35
 		}
-		goto tr333
-	st326:
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st340
+		}
+		goto st0
+	st340:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof326
+			goto _testEof340
 		}
-	stCase326:
-		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 51 {
-			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-			if m.rfc3339 {
-				_widec += 256
-			}
+	stCase340:
+		if (m.data)[(m.p)] == 32 {
+			goto tr35
 		}
-		if 560 <= _widec && _widec <= 563 {
-			goto st312
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st341
 		}
 		goto st0
-	st327:
+	st341:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof327
+			goto _testEof341
 		}
-	stCase327:
-		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
-			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-			if m.rfc3339 {
-				_widec += 256
-			}
+	stCase341:
+		if (m.data)[(m.p)] == 32 {
+			goto tr35
 		}
-		if 560 <= _widec && _widec <= 569 {
-			goto st309
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st342
 		}
 		goto st0
-	st328:
+	st342:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof328
+			goto _testEof342
 		}
-	stCase328:
-		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
-			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-			if m.rfc3339 {
-				_widec += 256
-			}
+	stCase342:
+		if (m.data)[(m.p)] == 32 {
+			goto tr35
 		}
-		if 560 <= _widec && _widec <= 561 {
-			goto st309
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st343
 		}
 		goto st0
-	st329:
+	st343:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof329
+			goto _testEof343
 		}
-	stCase329:
-		_widec = int16((m.data)[(m.p)])
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 50 {
-			_widec = 256 + (int16((m.data)[(m.p)]) - 0)
-			if m.rfc3339 {
-				_widec += 256
-			}
+	stCase343:
+		if (m.data)[(m.p)] == 32 {
+			goto tr35
 		}
-		if 560 <= _widec && _widec <= 562 {
-			goto st306
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st344
 		}
 		goto st0
-	tr4:
-
-		m.pb = m.p
-
-		goto st330
-	st330:
+	st344:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof330
+			goto _testEof344
 		}
-	stCase330:
-
-		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
-		output.prioritySet = true
-
-		switch (m.data)[(m.p)] {
-		case 57:
-			goto st332
-		case 62:
-			goto st4
+	stCase344:
+		if (m.data)[(m.p)] == 32 {
+			goto tr35
 		}
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 56 {
-			goto st331
+		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
+			goto st345
 		}
-		goto tr2
-	tr5:
-
-		m.pb = m.p
-
-		goto st331
-	st331:
+		goto st0
+	st345:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof331
+			goto _testEof345
 		}
-	stCase331:
-
-		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
-		output.prioritySet = true
-
-		if (m.data)[(m.p)] == 62 {
-			goto st4
+	stCase345:
+		if (m.data)[(m.p)] == 32 {
+			goto tr35
 		}
 		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 57 {
-			goto st3
+			goto st346
 		}
-		goto tr2
-	st332:
+		goto st0
+	st346:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof332
-		}
-	stCase332:
-
-		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
-		output.prioritySet = true
-
-		if (m.data)[(m.p)] == 62 {
-			goto st4
+			goto _testEof346
 		}
-		if 48 <= (m.data)[(m.p)] && (m.data)[(m.p)] <= 49 {
-			goto st3
+	stCase346:
+		if (m.data)[(m.p)] == 32 {
+			goto tr35
 		}
-		goto tr2
-	st373:
+		goto st0
+	st348:
 		if (m.p)++; (m.p) == (m.pe) {
-			goto _testEof373
+			goto _testEof348
 		}
-	stCase373:
+	stCase348:
 		switch (m.data)[(m.p)] {
 		case 10:
 			goto st0
 		case 13:
 			goto st0
 		}
-		goto st373
+		goto st348
 	stOut:
 	_testEof2:
 		m.cs = 2
@@ -6119,114 +5422,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	_testEof22:
 		m.cs = 22
 		goto _testEof
-	_testEof333:
-		m.cs = 333
-		goto _testEof
-	_testEof334:
-		m.cs = 334
-		goto _testEof
-	_testEof335:
-		m.cs = 335
-		goto _testEof
-	_testEof336:
-		m.cs = 336
-		goto _testEof
-	_testEof337:
-		m.cs = 337
-		goto _testEof
-	_testEof338:
-		m.cs = 338
-		goto _testEof
-	_testEof339:
-		m.cs = 339
-		goto _testEof
-	_testEof340:
-		m.cs = 340
-		goto _testEof
-	_testEof341:
-		m.cs = 341
-		goto _testEof
-	_testEof342:
-		m.cs = 342
-		goto _testEof
-	_testEof343:
-		m.cs = 343
-		goto _testEof
-	_testEof344:
-		m.cs = 344
-		goto _testEof
-	_testEof345:
-		m.cs = 345
-		goto _testEof
-	_testEof346:
-		m.cs = 346
-		goto _testEof
 	_testEof347:
 		m.cs = 347
 		goto _testEof
-	_testEof348:
-		m.cs = 348
-		goto _testEof
-	_testEof349:
-		m.cs = 349
-		goto _testEof
-	_testEof350:
-		m.cs = 350
-		goto _testEof
-	_testEof351:
-		m.cs = 351
-		goto _testEof
-	_testEof352:
-		m.cs = 352
-		goto _testEof
-	_testEof353:
-		m.cs = 353
-		goto _testEof
-	_testEof354:
-		m.cs = 354
-		goto _testEof
-	_testEof355:
-		m.cs = 355
-		goto _testEof
-	_testEof356:
-		m.cs = 356
-		goto _testEof
-	_testEof357:
-		m.cs = 357
-		goto _testEof
-	_testEof358:
-		m.cs = 358
-		goto _testEof
-	_testEof359:
-		m.cs = 359
-		goto _testEof
-	_testEof360:
-		m.cs = 360
-		goto _testEof
-	_testEof361:
-		m.cs = 361
-		goto _testEof
-	_testEof362:
-		m.cs = 362
-		goto _testEof
-	_testEof363:
-		m.cs = 363
-		goto _testEof
-	_testEof364:
-		m.cs = 364
-		goto _testEof
-	_testEof365:
-		m.cs = 365
-		goto _testEof
-	_testEof366:
-		m.cs = 366
-		goto _testEof
-	_testEof367:
-		m.cs = 367
-		goto _testEof
-	_testEof368:
-		m.cs = 368
-		goto _testEof
 	_testEof23:
 		m.cs = 23
 		goto _testEof
@@ -6239,18 +5437,6 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	_testEof26:
 		m.cs = 26
 		goto _testEof
-	_testEof369:
-		m.cs = 369
-		goto _testEof
-	_testEof370:
-		m.cs = 370
-		goto _testEof
-	_testEof371:
-		m.cs = 371
-		goto _testEof
-	_testEof372:
-		m.cs = 372
-		goto _testEof
 	_testEof27:
 		m.cs = 27
 		goto _testEof
@@ -7169,8 +6355,50 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	_testEof332:
 		m.cs = 332
 		goto _testEof
-	_testEof373:
-		m.cs = 373
+	_testEof333:
+		m.cs = 333
+		goto _testEof
+	_testEof334:
+		m.cs = 334
+		goto _testEof
+	_testEof335:
+		m.cs = 335
+		goto _testEof
+	_testEof336:
+		m.cs = 336
+		goto _testEof
+	_testEof337:
+		m.cs = 337
+		goto _testEof
+	_testEof338:
+		m.cs = 338
+		goto _testEof
+	_testEof339:
+		m.cs = 339
+		goto _testEof
+	_testEof340:
+		m.cs = 340
+		goto _testEof
+	_testEof341:
+		m.cs = 341
+		goto _testEof
+	_testEof342:
+		m.cs = 342
+		goto _testEof
+	_testEof343:
+		m.cs = 343
+		goto _testEof
+	_testEof344:
+		m.cs = 344
+		goto _testEof
+	_testEof345:
+		m.cs = 345
+		goto _testEof
+	_testEof346:
+		m.cs = 346
+		goto _testEof
+	_testEof348:
+		m.cs = 348
 		goto _testEof
 
 	_testEof:
@@ -7178,9 +6406,12 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 		if (m.p) == (m.eof) {
 			switch m.cs {
-			case 333, 334, 335, 336, 337, 338, 339, 340, 341, 342, 343, 344, 345, 346, 347, 348, 349, 350, 351, 352, 353, 354, 355, 356, 357, 358, 359, 360, 361, 362, 363, 364, 365, 366, 367, 368, 369, 370, 371, 372:
+			case 347:
 
-				output.message = string(m.text())
+				output.msg = string(m.text())
+				if output.raw != nil {
+					output.raw.Msg = m.span()
+				}
 
 			case 1:
 
@@ -7188,59 +6419,59 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				(m.p)--
 
 				{
-					goto st373
+					goto st348
 				}
 
-			case 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 281, 282, 283, 284, 285, 286, 287, 288, 289, 290, 291, 292, 293, 294, 295, 296, 297, 298, 299:
+			case 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 277, 278, 279, 280, 281, 282, 283, 284, 285, 286, 287, 288, 289, 290, 291, 292, 293, 294, 295, 329, 330, 331, 332, 333, 334, 335, 336, 337:
 
 				m.err = fmt.Errorf(errTimestamp, m.p)
 				(m.p)--
 
 				{
-					goto st373
+					goto st348
 				}
 
-			case 318, 319, 320, 321, 322, 323, 325:
+			case 314, 315, 316, 317, 318, 319, 321:
 
 				m.err = fmt.Errorf(errRFC3339, m.p)
 				(m.p)--
 
 				{
-					goto st373
+					goto st348
 				}
 
-			case 20, 21, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 127, 128, 129, 130, 131, 132, 133, 134, 135, 136, 137, 138, 139, 140, 141, 142, 143, 144, 145, 146, 147, 148, 149, 150, 151, 152, 153, 154, 155, 156, 157, 158, 159, 160, 161, 162, 163, 164, 165, 166, 167, 168, 169, 170, 171, 172, 173, 174, 175, 176, 177, 178, 179, 180, 181, 182, 183, 184, 185, 186, 187, 188, 189, 190, 191, 192, 193, 194, 195, 196, 197, 198, 199, 200, 201, 202, 203, 204, 205, 206, 207, 208, 209, 210, 211, 212, 213, 214, 215, 216, 217, 218, 219, 220, 221, 222, 223, 224, 225, 226, 227, 228, 229, 230, 231, 232, 233, 234, 235, 236, 237, 238, 239, 240, 241, 242, 243, 244, 245, 246, 247, 248, 249, 250, 251, 252, 253, 254, 255, 256, 257, 258, 259, 260, 261, 262, 263, 264, 265, 266, 267, 268, 269, 270, 271, 272, 273, 274, 275, 276, 277, 278, 279, 280:
+			case 20, 21, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 127, 128, 129, 130, 131, 132, 133, 134, 135, 136, 137, 138, 139, 140, 141, 142, 143, 144, 145, 146, 147, 148, 149, 150, 151, 152, 153, 154, 155, 156, 157, 158, 159, 160, 161, 162, 163, 164, 165, 166, 167, 168, 169, 170, 171, 172, 173, 174, 175, 176, 177, 178, 179, 180, 181, 182, 183, 184, 185, 186, 187, 188, 189, 190, 191, 192, 193, 194, 195, 196, 197, 198, 199, 200, 201, 202, 203, 204, 205, 206, 207, 208, 209, 210, 211, 212, 213, 214, 215, 216, 217, 218, 219, 220, 221, 222, 223, 224, 225, 226, 227, 228, 229, 230, 231, 232, 233, 234, 235, 236, 237, 238, 239, 240, 241, 242, 243, 244, 245, 246, 247, 248, 249, 250, 251, 252, 253, 254, 255, 256, 257, 258, 259, 260, 261, 262, 263, 264, 265, 266, 267, 268, 269, 270, 271, 272, 273, 274, 275, 276:
 
 				m.err = fmt.Errorf(errHostname, m.p)
 				(m.p)--
 
 				{
-					goto st373
+					goto st348
 				}
 
 			case 22:
 
-				m.err = fmt.Errorf(errTag, m.p)
+				m.err = fmt.Errorf(errMsg, m.p)
 				(m.p)--
 
 				{
-					goto st373
+					goto st348
 				}
 
-			case 2, 3, 330, 331, 332:
+			case 2, 3, 326, 327, 328:
 
 				m.err = fmt.Errorf(errPrival, m.p)
 				(m.p)--
 
 				{
-					goto st373
+					goto st348
 				}
 
 				m.err = fmt.Errorf(errPri, m.p)
 				(m.p)--
 
 				{
-					goto st373
+					goto st348
 				}
 
 			}
@@ -7252,6 +6483,10 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	}
 
 	if m.cs < firstFinal || m.cs == enFail {
+		// The input ended before the message was complete
+		if m.err == nil {
+			m.err = fmt.Errorf(errParse, m.p)
+		}
 		if m.bestEffort && output.minimal() {
 			// An error occurred but partial parsing is on and partial message is minimally valid
 			return output.export(), m.err
@@ -14,12 +14,13 @@ var (
 	errTimestamp      = "expecting a Stamp timestamp [col %d]"
 	errRFC3339        = "expecting a Stamp or a RFC3339 timestamp [col %d]"
 	errHostname       = "expecting an hostname (from 1 to max 255 US-ASCII characters) [col %d]"
-	errTag            = "expecting an alphanumeric tag (max 32 characters) [col %d]"
-	errContentStart   = "expecting a content part starting with a non-alphanumeric character [col %d]"
-	errContent        = "expecting a content part composed by visible characters only [col %d]"
+	errMsg            = "expecting a message composed by visible characters only [col %d]"
 	errParse          = "parsing error [col %d]"
 )
 
+// stampWithYear is the Stamp layout having a 4-digit year before the time, as sent by some network devices.
+const stampWithYear = "Jan _2 2006 15:04:05"
+
 %%{
 machine rfc3164;
 
@@ -35,22 +36,25 @@ action mark {
 action set_prival {
 	output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
 	output.prioritySet = true
+	if output.raw != nil {
+		output.raw.Priority = m.span()
+	}
 }
 
 action set_timestamp {
-	if t, e := time.Parse(time.Stamp, string(m.text())); e != nil {
+	if t, e := m.parseStamp(m.text()); e != nil {
 		m.err = fmt.Errorf("%s [col %d]", e, m.p)
 		fhold;
 		fgoto fail;
 	} else {
-		if m.timezone != nil {
-			t, _ = time.ParseInLocation(time.Stamp, string(m.text()), m.timezone)
-		}
-		output.timestamp = t.AddDate(m.yyyy, 0, 0)
+		output.timestamp = t
 		if m.loc != nil {
 			output.timestamp = output.timestamp.In(m.loc)
 		}
 		output.timestampSet = true
+		if output.raw != nil {
+			output.raw.Timestamp = m.span()
+		}
 	}
 }
 
@@ -62,23 +66,24 @@ action set_rfc3339 {
 	} else {
 		output.timestamp = t
 		output.timestampSet = true
+		if output.raw != nil {
+			output.raw.Timestamp = m.span()
+		}
 	}
 }
 
 action set_hostname {
 	output.hostname = string(m.text())
+	if output.raw != nil {
+		output.raw.Hostname = m.span()
+	}
 }
 
-action set_tag {
-	output.tag = string(m.text())
-}
-
-action set_content {
-	output.content = string(m.text())
-}
-
-action set_message {
-	output.message = string(m.text())
+action set_msg {
+	output.msg = string(m.text())
+	if output.raw != nil {
+		output.raw.Msg = m.span()
+	}
 }
 
 action err_prival {
@@ -111,27 +116,18 @@ action err_hostname {
 	fgoto fail;
 }
 
-action err_tag {
-	m.err = fmt.Errorf(errTag, m.p)
-	fhold;
-	fgoto fail;
-}
-
-action err_contentstart {
-	m.err = fmt.Errorf(errContentStart, m.p)
-	fhold;
-	fgoto fail;
-}
-
-action err_content {
-	m.err = fmt.Errorf(errContent, m.p)
+action err_msg {
+	m.err = fmt.Errorf(errMsg, m.p)
 	fhold;
 	fgoto fail;
 }
 
 pri = ('<' prival >mark %from(set_prival) $err(err_prival) '>') @err(err_pri);
 
-timestamp = (datemmm sp datemday sp hhmmss) >mark %set_timestamp @err(err_timestamp);
+# Fractional seconds up to nanoseconds - ie., StampMilli, StampMicro, StampNano
+stampsecfrac = '.' digit{1,9};
+
+timestamp = (datemmm sp datemday sp (datefullyear sp)? hhmmss stampsecfrac?) >mark %set_timestamp @err(err_timestamp);
 
 rfc3339 = fulldate >mark 'T' hhmmss timeoffset %set_rfc3339 @err(err_rfc3339);
 
@@ -139,22 +135,19 @@ rfc3339 = fulldate >mark 'T' hhmmss timeoffset %set_rfc3339 @err(err_rfc3339);
 # note > this could mean that the we may need to create and to use a labelrange = graph{1,63} here if we want the parser to be stricter.
 hostname = hostnamerange >mark %set_hostname $err(err_hostname);
 
-# Section 4.1.3
-# note > alnum{1,32} is too restrictive (eg., no dashes)
-# note > see https://tools.ietf.org/html/rfc2234#section-2.1 for an interpretation of "ABNF alphanumeric" as stated by RFC 3164 regarding the tag
-# note > while RFC3164 assumes only ABNF alphanumeric process names, many BSD-syslog contains processe names with additional characters (-, _, .)
-tag = (print -- [ :\[]){1,32} >mark %set_tag @err(err_tag);
-
-visible = print | 0x80..0xFF;
-
-# The first not alphanumeric character starts the content (usually containing a PID) part of the message part
-contentval = !alnum @err(err_contentstart) >mark print* %set_content @err(err_content);
+# HTAB is allowed since some payloads use it as a separator (eg., LEEF attributes)
+visible = print | '\t' | 0x80..0xFF;
 
-content = '[' contentval ']'; # todo(leodido) > support ':' and ' ' too. Also they have to match?
+# Section 5.3
+# note > these machines only document the TAG and the CONTENT, since splitMsg splits them out of the MSG
+# note > while RFC3164 assumes only ABNF alphanumeric process names, many BSD-syslog messages contain process names with additional characters (-, _, .)
+tag = (print -- [ :\[]){1,32};
 
-mex = visible+ >mark %set_message;
+content = '[' (visible - ']')* ']';
 
-msg = (tag content? ':' sp)? mex;
+# Section 4.1.3
+# note > TAG and CONTENT are split out of the MSG afterwards (see splitMsg) since the grammar alone cannot tell them apart from the free-form text
+msg = visible+ >mark %set_msg @err(err_msg);
 
 fail := (any - [\n\r])* @err{ fgoto main; };
 
@@ -175,6 +168,7 @@ type machine struct {
 	rfc3339      bool
 	loc          *time.Location
 	timezone     *time.Location
+	raw          bool
 }
 
 // NewMachine creates a new FSM able to parse RFC3164 syslog messages.
@@ -226,6 +220,11 @@ func (m *machine) WithRFC3339() {
 	m.rfc3339 = true
 }
 
+// WithRaw enables the recording of the raw input and of the positions of the parts of the messages.
+func (m *machine) WithRaw() {
+	m.raw = true
+}
+
 // Err returns the error that occurred on the last call to Parse.
 //
 // If the result is nil, then the line was parsed successfully.
@@ -237,6 +236,30 @@ func (m *machine) text() []byte {
 	return m.data[m.pb:m.p]
 }
 
+func (m *machine) span() syslog.Span {
+	return syslog.Span{Start: m.pb, End: m.p}
+}
+
+// parseStamp parses a Stamp timestamp, also when it has fractional seconds or a 4-digit year after the day.
+//
+// The year strategy applies only to timestamps without their own year.
+func (m *machine) parseStamp(ts []byte) (time.Time, error) {
+	loc := time.UTC
+	if m.timezone != nil {
+		loc = m.timezone
+	}
+	// Mmm dd yyyy hh:mm:ss
+	if len(ts) > 9 && ts[9] != ':' {
+		return time.ParseInLocation(stampWithYear, string(ts), loc)
+	}
+	t, err := time.ParseInLocation(time.Stamp, string(ts), loc)
+	if err != nil {
+		return t, err
+	}
+
+	return t.AddDate(m.yyyy, 0, 0), nil
+}
+
 // Parse parses the input byte array as a RFC3164 syslog message.
 func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	m.data = input
@@ -246,11 +269,18 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	m.eof = len(input)
 	m.err = nil
 	output := &syslogMessage{}
+	if m.raw {
+		output.raw = &Raw{Input: append([]byte(nil), input...)}
+	}
 
 	%% write init;
 	%% write exec;
 
 	if m.cs < first_final || m.cs == en_fail {
+		// The input ended before the message was complete
+		if m.err == nil {
+			m.err = fmt.Errorf(errParse, m.p)
+		}
 		if m.bestEffort && output.minimal() {
 			// An error occurred but partial parsing is on and partial message is minimally valid
 			return output.export(), m.err
@@ -1,6 +1,7 @@
 package rfc3164
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -33,6 +34,9 @@ var testCases = []testCase{
 				Appname:   syslogtesting.StringAddress("apache"),
 				Message:   syslogtesting.StringAddress(`1.2.3.4 - - [12/Jan/2011:06:29:59 +0100] "GET /foo/bar.html HTTP/1.1" 301 96 "-" "Mozilla/5.0 (Windows; U; Windows NT 5.1; fr; rv:1.9.2.12) Gecko/20101026 Firefox/3.6.12 ( .NET CLR 3.5.30729)" PID 18904 Time Taken 0`),
 			},
+			Msg:       syslogtesting.StringAddress(`apache: 1.2.3.4 - - [12/Jan/2011:06:29:59 +0100] "GET /foo/bar.html HTTP/1.1" 301 96 "-" "Mozilla/5.0 (Windows; U; Windows NT 5.1; fr; rv:1.9.2.12) Gecko/20101026 Firefox/3.6.12 ( .NET CLR 3.5.30729)" PID 18904 Time Taken 0`),
+			Tag:       syslogtesting.StringAddress("apache"),
+			Separator: syslogtesting.StringAddress(": "),
 		},
 		"",
 		nil,
@@ -50,6 +54,9 @@ var testCases = []testCase{
 				Appname:   syslogtesting.StringAddress("aaa"),
 				Message:   syslogtesting.StringAddress(`message from 1.2.3.4`),
 			},
+			Msg:       syslogtesting.StringAddress(`aaa: message from 1.2.3.4`),
+			Tag:       syslogtesting.StringAddress("aaa"),
+			Separator: syslogtesting.StringAddress(": "),
 		},
 		"",
 		nil,
@@ -68,6 +75,11 @@ var testCases = []testCase{
 				ProcID:    syslogtesting.StringAddress("6040"),
 				Message:   syslogtesting.StringAddress(`ec2-user : TTY=pts/0 ; PWD=/var/log ; USER=root ; COMMAND=/bin/tail secure`),
 			},
+			Msg:       syslogtesting.StringAddress(`sudo[6040]: ec2-user : TTY=pts/0 ; PWD=/var/log ; USER=root ; COMMAND=/bin/tail secure`),
+			Tag:       syslogtesting.StringAddress("sudo"),
+			Content:   syslogtesting.StringAddress("6040"),
+			PID:       syslogtesting.IntAddress(6040),
+			Separator: syslogtesting.StringAddress(": "),
 		},
 	},
 	{
@@ -80,8 +92,13 @@ var testCases = []testCase{
 				Severity:  syslogtesting.Uint8Address(6),
 				Timestamp: syslogtesting.TimeParse(time.Stamp, "Jul  6 20:33:28"),
 				Hostname:  syslogtesting.StringAddress("ABC-1-234567"),
-				Message:   syslogtesting.StringAddress("Some message here"),
+				Appname:   syslogtesting.StringAddress("Some"),
+				Message:   syslogtesting.StringAddress("message here"),
 			},
+			// A space ends the TAG, too (RFC3164 section 5.3)
+			Msg:       syslogtesting.StringAddress(`Some message here`),
+			Tag:       syslogtesting.StringAddress("Some"),
+			Separator: syslogtesting.StringAddress(" "),
 		},
 	},
 	{
@@ -97,6 +114,9 @@ var testCases = []testCase{
 				Appname:   syslogtesting.StringAddress("su"),
 				Message:   syslogtesting.StringAddress("'su root' failed for lonvick on /dev/pts/8"),
 			},
+			Msg:       syslogtesting.StringAddress(`su: 'su root' failed for lonvick on /dev/pts/8`),
+			Tag:       syslogtesting.StringAddress("su"),
+			Separator: syslogtesting.StringAddress(": "),
 		},
 	},
 	{
@@ -109,8 +129,13 @@ var testCases = []testCase{
 				Severity:  syslogtesting.Uint8Address(5),
 				Timestamp: syslogtesting.TimeParse(time.Stamp, "Feb  5 17:32:18"),
 				Hostname:  syslogtesting.StringAddress("10.0.0.99"),
-				Message:   syslogtesting.StringAddress("Use the BFG!"),
+				Appname:   syslogtesting.StringAddress("Use"),
+				Message:   syslogtesting.StringAddress("the BFG!"),
 			},
+			// A space ends the TAG, too (RFC3164 section 5.3)
+			Msg:       syslogtesting.StringAddress(`Use the BFG!`),
+			Tag:       syslogtesting.StringAddress("Use"),
+			Separator: syslogtesting.StringAddress(" "),
 		},
 	},
 	{
@@ -127,6 +152,11 @@ var testCases = []testCase{
 				ProcID:    syslogtesting.StringAddress("10"),
 				Message:   syslogtesting.StringAddress(`%% It's time to make the do-nuts.  %%  Ingredients: Mix=OK, Jelly=OK # Devices: Mixer=OK, Jelly_Injector=OK, Frier=OK # Transport: Conveyer1=OK, Conveyer2=OK # %%`),
 			},
+			Msg:       syslogtesting.StringAddress(`myproc[10]: %% It's time to make the do-nuts.  %%  Ingredients: Mix=OK, Jelly=OK # Devices: Mixer=OK, Jelly_Injector=OK, Frier=OK # Transport: Conveyer1=OK, Conveyer2=OK # %%`),
+			Tag:       syslogtesting.StringAddress("myproc"),
+			Content:   syslogtesting.StringAddress("10"),
+			PID:       syslogtesting.IntAddress(10),
+			Separator: syslogtesting.StringAddress(": "),
 		},
 	},
 	{
@@ -143,6 +173,504 @@ var testCases = []testCase{
 				ProcID:    syslogtesting.StringAddress("0"),
 				Message:   syslogtesting.StringAddress(`That's All Folks!`),
 			},
+			Msg:       syslogtesting.StringAddress(`sched[0]: That's All Folks!`),
+			Tag:       syslogtesting.StringAddress("sched"),
+			Content:   syslogtesting.StringAddress("0"),
+			PID:       syslogtesting.IntAddress(0),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<34>Jan 12 06:30:00.123 xxx apache: message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(34),
+				Facility:  syslogtesting.Uint8Address(4),
+				Severity:  syslogtesting.Uint8Address(2),
+				Timestamp: syslogtesting.TimeParse(time.StampMilli, "Jan 12 06:30:00.123"),
+				Hostname:  syslogtesting.StringAddress("xxx"),
+				Appname:   syslogtesting.StringAddress("apache"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`apache: message`),
+			Tag:       syslogtesting.StringAddress("apache"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<34>Jan 12 06:30:00.123456 xxx apache: message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(34),
+				Facility:  syslogtesting.Uint8Address(4),
+				Severity:  syslogtesting.Uint8Address(2),
+				Timestamp: syslogtesting.TimeParse(time.StampMicro, "Jan 12 06:30:00.123456"),
+				Hostname:  syslogtesting.StringAddress("xxx"),
+				Appname:   syslogtesting.StringAddress("apache"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`apache: message`),
+			Tag:       syslogtesting.StringAddress("apache"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<34>Jan 12 06:30:00.123456789 xxx apache: message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(34),
+				Facility:  syslogtesting.Uint8Address(4),
+				Severity:  syslogtesting.Uint8Address(2),
+				Timestamp: syslogtesting.TimeParse(time.StampNano, "Jan 12 06:30:00.123456789"),
+				Hostname:  syslogtesting.StringAddress("xxx"),
+				Appname:   syslogtesting.StringAddress("apache"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`apache: message`),
+			Tag:       syslogtesting.StringAddress("apache"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<34>Jan 12 06:30:00.1 xxx apache: message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(34),
+				Facility:  syslogtesting.Uint8Address(4),
+				Severity:  syslogtesting.Uint8Address(2),
+				Timestamp: syslogtesting.TimeParse(time.StampMilli, "Jan 12 06:30:00.100"),
+				Hostname:  syslogtesting.StringAddress("xxx"),
+				Appname:   syslogtesting.StringAddress("apache"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`apache: message`),
+			Tag:       syslogtesting.StringAddress("apache"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<34>Dec  2 2021 16:31:03 xxx apache: message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(34),
+				Facility:  syslogtesting.Uint8Address(4),
+				Severity:  syslogtesting.Uint8Address(2),
+				Timestamp: syslogtesting.TimeParse(stampWithYear, "Dec  2 2021 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("xxx"),
+				Appname:   syslogtesting.StringAddress("apache"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`apache: message`),
+			Tag:       syslogtesting.StringAddress("apache"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<34>Dec  2 1999 23:59:59.999999 xxx apache: message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(34),
+				Facility:  syslogtesting.Uint8Address(4),
+				Severity:  syslogtesting.Uint8Address(2),
+				Timestamp: syslogtesting.TimeParse("Jan _2 2006 15:04:05.000000", "Dec  2 1999 23:59:59.999999"),
+				Hostname:  syslogtesting.StringAddress("xxx"),
+				Appname:   syslogtesting.StringAddress("apache"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`apache: message`),
+			Tag:       syslogtesting.StringAddress("apache"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<34>Mar 31 0001 00:00:00 xxx apache: message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(34),
+				Facility:  syslogtesting.Uint8Address(4),
+				Severity:  syslogtesting.Uint8Address(2),
+				Timestamp: syslogtesting.TimeParse(stampWithYear, "Mar 31 0001 00:00:00"),
+				Hostname:  syslogtesting.StringAddress("xxx"),
+				Appname:   syslogtesting.StringAddress("apache"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`apache: message`),
+			Tag:       syslogtesting.StringAddress("apache"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input:       []byte(`<34>Jan 12 06:30:00. xxx apache: message`),
+		errorString: "expecting a Stamp timestamp [col 20]",
+		partialValue: &SyslogMessage{
+			Base: syslog.Base{
+				Priority: syslogtesting.Uint8Address(34),
+				Facility: syslogtesting.Uint8Address(4),
+				Severity: syslogtesting.Uint8Address(2),
+			},
+		},
+	},
+	{
+		input:       []byte(`<34>Dec  2 2021 24:31:03 xxx apache: message`),
+		errorString: "expecting a Stamp timestamp [col 17]",
+		partialValue: &SyslogMessage{
+			Base: syslog.Base{
+				Priority: syslogtesting.Uint8Address(34),
+				Facility: syslogtesting.Uint8Address(4),
+				Severity: syslogtesting.Uint8Address(2),
+			},
+		},
+	},
+	{
+		input:       []byte(`<34>Dec  2 20 16:31:03 xxx apache: message`),
+		errorString: "expecting a Stamp timestamp [col 13]",
+		partialValue: &SyslogMessage{
+			Base: syslog.Base{
+				Priority: syslogtesting.Uint8Address(34),
+				Facility: syslogtesting.Uint8Address(4),
+				Severity: syslogtesting.Uint8Address(2),
+			},
+		},
+	},
+	{
+		input:       []byte(`<34>Dec  2 20211 16:31:03 xxx apache: message`),
+		errorString: "expecting a Stamp timestamp [col 15]",
+		partialValue: &SyslogMessage{
+			Base: syslog.Base{
+				Priority: syslogtesting.Uint8Address(34),
+				Facility: syslogtesting.Uint8Address(4),
+				Severity: syslogtesting.Uint8Address(2),
+			},
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host kernel:message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("kernel"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`kernel:message`),
+			Tag:       syslogtesting.StringAddress("kernel"),
+			Separator: syslogtesting.StringAddress(":"),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host kernel:   message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("kernel"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`kernel:   message`),
+			Tag:       syslogtesting.StringAddress("kernel"),
+			Separator: syslogtesting.StringAddress(":   "),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host sudo[6040] hello`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("sudo"),
+				ProcID:    syslogtesting.StringAddress("6040"),
+				Message:   syslogtesting.StringAddress(`hello`),
+			},
+			Msg:       syslogtesting.StringAddress(`sudo[6040] hello`),
+			Tag:       syslogtesting.StringAddress("sudo"),
+			Content:   syslogtesting.StringAddress("6040"),
+			PID:       syslogtesting.IntAddress(6040),
+			Separator: syslogtesting.StringAddress(" "),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host tag[1] message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("tag"),
+				ProcID:    syslogtesting.StringAddress("1"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`tag[1] message`),
+			Tag:       syslogtesting.StringAddress("tag"),
+			Content:   syslogtesting.StringAddress("1"),
+			PID:       syslogtesting.IntAddress(1),
+			Separator: syslogtesting.StringAddress(" "),
+		},
+	},
+	// A space ends the TAG without a CONTENT, too
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host tag message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("tag"),
+				Message:   syslogtesting.StringAddress(`message`),
+			},
+			Msg:       syslogtesting.StringAddress(`tag message`),
+			Tag:       syslogtesting.StringAddress("tag"),
+			Separator: syslogtesting.StringAddress(" "),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host tag   several spaces`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("tag"),
+				Message:   syslogtesting.StringAddress(`several spaces`),
+			},
+			Msg:       syslogtesting.StringAddress(`tag   several spaces`),
+			Tag:       syslogtesting.StringAddress("tag"),
+			Separator: syslogtesting.StringAddress("   "),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host sudo[60a40]:x hello`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("sudo"),
+				ProcID:    syslogtesting.StringAddress("60a40"),
+				Message:   syslogtesting.StringAddress(`x hello`),
+			},
+			Msg:       syslogtesting.StringAddress(`sudo[60a40]:x hello`),
+			Tag:       syslogtesting.StringAddress("sudo"),
+			Content:   syslogtesting.StringAddress("60a40"),
+			Separator: syslogtesting.StringAddress(":"),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host sshd[]: Accepted publickey`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("sshd"),
+				Message:   syslogtesting.StringAddress(`Accepted publickey`),
+			},
+			Msg:       syslogtesting.StringAddress(`sshd[]: Accepted publickey`),
+			Tag:       syslogtesting.StringAddress("sshd"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host cron[123]`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("cron"),
+				ProcID:    syslogtesting.StringAddress("123"),
+			},
+			Msg:     syslogtesting.StringAddress(`cron[123]`),
+			Tag:     syslogtesting.StringAddress("cron"),
+			Content: syslogtesting.StringAddress("123"),
+			PID:     syslogtesting.IntAddress(123),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host app:`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("app"),
+			},
+			Msg:       syslogtesting.StringAddress(`app:`),
+			Tag:       syslogtesting.StringAddress("app"),
+			Separator: syslogtesting.StringAddress(":"),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host app: `),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("app"),
+			},
+			Msg:       syslogtesting.StringAddress(`app: `),
+			Tag:       syslogtesting.StringAddress("app"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host app[1]x message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Message:   syslogtesting.StringAddress(`app[1]x message`),
+			},
+			Msg: syslogtesting.StringAddress(`app[1]x message`),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host app[1 message`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Message:   syslogtesting.StringAddress(`app[1 message`),
+			},
+			Msg: syslogtesting.StringAddress(`app[1 message`),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host abcdefghijabcdefghijabcdefghijab: 32 characters tag`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Appname:   syslogtesting.StringAddress("abcdefghijabcdefghijabcdefghijab"),
+				Message:   syslogtesting.StringAddress(`32 characters tag`),
+			},
+			Msg:       syslogtesting.StringAddress(`abcdefghijabcdefghijabcdefghijab: 32 characters tag`),
+			Tag:       syslogtesting.StringAddress("abcdefghijabcdefghijabcdefghijab"),
+			Separator: syslogtesting.StringAddress(": "),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host abcdefghijabcdefghijabcdefghijabc: 33 characters tag`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Message:   syslogtesting.StringAddress(`abcdefghijabcdefghijabcdefghijabc: 33 characters tag`),
+			},
+			Msg: syslogtesting.StringAddress(`abcdefghijabcdefghijabcdefghijabc: 33 characters tag`),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host :no tag`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Message:   syslogtesting.StringAddress(`:no tag`),
+			},
+			Msg: syslogtesting.StringAddress(`:no tag`),
+		},
+	},
+	{
+		input: []byte(`<13>Dec  2 16:31:03 host [1]: no tag`),
+		valid: true,
+		value: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+				Message:   syslogtesting.StringAddress(`[1]: no tag`),
+			},
+			Msg: syslogtesting.StringAddress(`[1]: no tag`),
+		},
+	},
+	{
+		input:       []byte("<13>Dec  2 16:31:03 host \x01"),
+		errorString: "expecting a message composed by visible characters only [col 25]",
+		partialValue: &SyslogMessage{
+			Base: syslog.Base{
+				Priority:  syslogtesting.Uint8Address(13),
+				Facility:  syslogtesting.Uint8Address(1),
+				Severity:  syslogtesting.Uint8Address(5),
+				Timestamp: syslogtesting.TimeParse(time.Stamp, "Dec  2 16:31:03"),
+				Hostname:  syslogtesting.StringAddress("host"),
+			},
+		},
+	},
+	{
+		input:       []byte("<13>Dec  2 16:31:03"),
+		errorString: "parsing error [col 19]",
+		partialValue: &SyslogMessage{
+			Base: syslog.Base{
+				Priority: syslogtesting.Uint8Address(13),
+				Facility: syslogtesting.Uint8Address(1),
+				Severity: syslogtesting.Uint8Address(5),
+			},
 		},
 	},
 	// todo > other test cases pleaaaase
@@ -176,3 +704,77 @@ func TestMachineParse(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineParseTruncatedRFC3339(t *testing.T) {
+	for _, input := range []string{"<13>2", "<13>2003-10-11T", "<13>2003-10-11T22:14:15Z"} {
+		message, err := NewMachine(WithRFC3339()).Parse([]byte(input))
+		assert.Nil(t, message)
+		assert.EqualError(t, err, fmt.Sprintf("parsing error [col %d]", len(input)), input)
+
+		partial, perr := NewMachine(WithRFC3339(), WithBestEffort()).Parse([]byte(input))
+		assert.NotNil(t, partial)
+		assert.Equal(t, err, perr)
+	}
+}
+
+func TestTagContentMapping(t *testing.T) {
+	cases := []struct {
+		input   string
+		tag     *string
+		content *string
+		pid     *int
+	}{
+		{"sshd[1234]: Accepted publickey", syslogtesting.StringAddress("sshd"), syslogtesting.StringAddress("1234"), syslogtesting.IntAddress(1234)},
+		{"app[worker-2]: Started", syslogtesting.StringAddress("app"), syslogtesting.StringAddress("worker-2"), nil},
+		{"app[+12]: Started", syslogtesting.StringAddress("app"), syslogtesting.StringAddress("+12"), nil},
+		{"app[99999999999999999999]: Started", syslogtesting.StringAddress("app"), syslogtesting.StringAddress("99999999999999999999"), nil},
+		{"kernel: Started", syslogtesting.StringAddress("kernel"), nil, nil},
+		{"Started", nil, nil, nil},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := NewMachine().Parse([]byte("<13>Dec  2 16:31:03 host " + tc.input))
+			assert.Nil(t, err)
+			msg := m.(*SyslogMessage)
+
+			assert.Equal(t, tc.tag, msg.Tag)
+			assert.Equal(t, tc.content, msg.Content)
+			assert.Equal(t, tc.pid, msg.PID)
+			// The TAG and the CONTENT are also available as APP-NAME and PROCID
+			assert.Equal(t, msg.Tag, msg.Appname)
+			assert.Equal(t, msg.Content, msg.ProcID)
+		})
+	}
+}
+
+func TestMachineParseWithRaw(t *testing.T) {
+	input := []byte("<13>Dec  2 16:31:03 host sshd[1234]: Accepted publickey")
+	m, err := NewMachine(WithRaw()).Parse(input)
+	assert.Nil(t, err)
+	raw := m.(*SyslogMessage).Raw
+	assert.NotNil(t, raw)
+
+	// The raw input is a copy
+	input[len(input)-1] = 'Y'
+	assert.Equal(t, "<13>Dec  2 16:31:03 host sshd[1234]: Accepted publickey", string(raw.Input))
+
+	text := func(s syslog.Span) string {
+		return string(raw.Input[s.Start:s.End])
+	}
+	assert.Equal(t, "13", text(raw.Priority))
+	assert.Equal(t, "Dec  2 16:31:03", text(raw.Timestamp))
+	assert.Equal(t, "host", text(raw.Hostname))
+	assert.Equal(t, "sshd[1234]: Accepted publickey", text(raw.Msg))
+	assert.Equal(t, "sshd", text(raw.Tag))
+	assert.Equal(t, "1234", text(raw.Content))
+	assert.Equal(t, ": ", text(raw.Separator))
+	assert.Equal(t, "Accepted publickey", text(raw.Message))
+
+	m, err = NewMachine().Parse(input)
+	assert.Nil(t, err)
+	assert.Nil(t, m.(*SyslogMessage).Raw)
+}
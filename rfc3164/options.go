@@ -66,3 +66,14 @@ func WithRFC3339() syslog.MachineOption {
 		return m
 	}
 }
+
+// WithRaw tells the parser to record the raw input and the positions of the parts of the RFC3164 syslog messages.
+//
+// The parsed messages will carry them in their Raw field,
+// so that tooling can highlight or re-slice the input exactly, also when best effort mode returns partial messages.
+func WithRaw() syslog.MachineOption {
+	return func(m syslog.Machine) syslog.Machine {
+		m.(*machine).WithRaw()
+		return m
+	}
+}
@@ -0,0 +1,38 @@
+package rfc3164
+
+import (
+	"github.com/influxdata/go-syslog/v3"
+)
+
+// Raw contains the input a RFC3164 syslog message has been parsed from, together with the positions of its parts.
+//
+// Parts that have not been parsed have a zero Span.
+type Raw struct {
+	Input     []byte
+	Priority  syslog.Span
+	Timestamp syslog.Span
+	Hostname  syslog.Span
+	Msg       syslog.Span
+	Tag       syslog.Span
+	Content   syslog.Span
+	Separator syslog.Span
+	Message   syslog.Span
+}
+
+// split sets the positions of the parts the MSG has been split into.
+func (r *Raw) split(tag, content, separator, message string) {
+	if tag != "" {
+		r.Tag = syslog.Span{Start: r.Msg.Start, End: r.Msg.Start + len(tag)}
+	}
+	if content != "" {
+		// Opening square bracket
+		r.Content = syslog.Span{Start: r.Tag.End + 1, End: r.Tag.End + 1 + len(content)}
+	}
+	if message != "" {
+		r.Message = syslog.Span{Start: r.Msg.End - len(message), End: r.Msg.End}
+	}
+	if separator != "" {
+		end := r.Msg.End - len(message)
+		r.Separator = syslog.Span{Start: end - len(separator), End: end}
+	}
+}
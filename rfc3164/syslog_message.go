@@ -1,21 +1,27 @@
 package rfc3164
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/influxdata/go-syslog/v3"
 	"github.com/influxdata/go-syslog/v3/common"
 )
 
+// Format is the name of the format of RFC3164 syslog messages.
+//
+// It is also the value of the "format" key of their JSON representation.
+const Format = "rfc3164"
+
 type syslogMessage struct {
 	prioritySet  bool // We explictly flag the setting of priority since its zero value is a valid priority by RFC 3164
 	timestampSet bool // We explictly flag the setting of timestamp since its zero value is a valid timestamp by RFC 3164
 	priority     uint8
 	timestamp    time.Time
 	hostname     string
-	tag          string
-	content      string
-	message      string
+	msg          string
+	raw          *Raw
 }
 
 func (sm *syslogMessage) minimal() bool {
@@ -34,22 +40,148 @@ func (sm *syslogMessage) export() *SyslogMessage {
 	if sm.hostname != "-" && sm.hostname != "" {
 		out.Hostname = &sm.hostname
 	}
-	if sm.tag != "-" && sm.tag != "" {
-		out.Appname = &sm.tag
+	out.Raw = sm.raw
+	if sm.msg == "" {
+		return out
+	}
+	out.Msg = &sm.msg
+
+	tag, content, separator, message := splitMsg(sm.msg)
+	if sm.raw != nil {
+		sm.raw.split(tag, content, separator, message)
+	}
+	out.setParts(tag, content, separator, message)
+
+	return out
+}
+
+// SetMsg sets the MSG part and the fields derived from it (ie., TAG, CONTENT, PID, separator, and message).
+//
+// It splits the MSG the same way the parser does.
+func (m *SyslogMessage) SetMsg(msg string) {
+	m.Msg = &msg
+	m.Tag = nil
+	m.Content = nil
+	m.PID = nil
+	m.Separator = nil
+	m.Appname = nil
+	m.ProcID = nil
+	m.Message = nil
+	m.setParts(splitMsg(msg))
+}
+
+// setParts sets the fields derived from the MSG part.
+func (m *SyslogMessage) setParts(tag, content, separator, message string) {
+	if tag != "-" && tag != "" {
+		appname := tag
+		m.Tag = &tag
+		m.Appname = &appname
 	}
-	if sm.content != "-" && sm.content != "" {
+	if content != "-" && content != "" {
+		procid := content
+		m.Content = &content
 		// Content is usually process ID
 		// See https://tools.ietf.org/html/rfc3164#section-5.3
-		out.ProcID = &sm.content
+		m.ProcID = &procid
+		if pid, ok := toPID(content); ok {
+			m.PID = &pid
+		}
+	}
+	if separator != "" {
+		m.Separator = &separator
+	}
+	if message != "" {
+		m.Message = &message
 	}
-	if sm.message != "" {
-		out.Message = &sm.message
+}
+
+// splitMsg splits the MSG part into TAG, CONTENT (ie., the text within square brackets), separator, and message.
+//
+// It follows the heuristics of RFC 3164 section 5.3.
+// The TAG is at most 32 printable characters except space, colon, and opening square bracket.
+// While RFC3164 assumes only ABNF alphanumeric process names, many BSD-syslog messages contain process names with additional characters (-, _, .).
+// The TAG ends at the first non-alphanumeric character it cannot contain: the opening square bracket of a CONTENT, a colon, or a space.
+// A CONTENT must be followed by a colon, a space, or by the end of the MSG.
+// The separator is made of the optional colon and of all the spaces following it.
+// When these conditions do not hold the whole MSG is the message.
+func splitMsg(msg string) (tag, content, separator, message string) {
+	t := 0
+	for t < len(msg) && t <= 32 && isTagChar(msg[t]) {
+		t++
+	}
+	if t == 0 || t > 32 || t == len(msg) {
+		return "", "", "", msg
 	}
 
-	return out
+	s := t
+	if msg[s] == '[' {
+		e := strings.IndexByte(msg[s:], ']')
+		if e < 0 {
+			return "", "", "", msg
+		}
+		content = msg[s+1 : s+e]
+		s += e + 1
+		if s < len(msg) && msg[s] != ':' && msg[s] != ' ' {
+			return "", "", "", msg
+		}
+	} else if msg[s] != ':' && msg[s] != ' ' {
+		return "", "", "", msg
+	}
+
+	e := s
+	if e < len(msg) && msg[e] == ':' {
+		e++
+	}
+	for e < len(msg) && msg[e] == ' ' {
+		e++
+	}
+
+	return msg[:t], content, msg[s:e], msg[e:]
+}
+
+// toPID converts the input CONTENT to a process ID when it is composed by decimal digits only.
+func toPID(content string) (int, bool) {
+	for i := 0; i < len(content); i++ {
+		if content[i] < '0' || content[i] > '9' {
+			return 0, false
+		}
+	}
+	pid, err := strconv.Atoi(content)
+
+	return pid, err == nil
+}
+
+// isTagChar tells whether the input character can be part of a TAG.
+func isTagChar(c byte) bool {
+	return c > ' ' && c < 0x7F && c != ':' && c != '['
 }
 
 // SyslogMessage represents a RFC3164 syslog message.
+//
+// Its TAG and CONTENT are also mapped to the Appname and ProcID fields of the embedded Base,
+// so that they can be handled like the APP-NAME and PROCID of RFC5424 syslog messages.
 type SyslogMessage struct {
 	syslog.Base
+	// Msg is the whole MSG part as received, ie., the TAG followed by the CONTENT.
+	Msg *string
+	// Tag is the TAG part, usually the name of the program or process that generated the message.
+	Tag *string
+	// Content is the text within square brackets right after the TAG.
+	Content *string
+	// PID is the process ID, only present when the Content is numeric.
+	PID *int
+	// Separator is the raw text between the TAG (or its CONTENT within square brackets) and the message, eg. ": ".
+	Separator *string
+	// Raw is only present when parsing with the WithRaw option.
+	Raw *Raw
+}
+
+// Format returns "rfc3164".
+func (m *SyslogMessage) Format() string {
+	return Format
+}
+
+// GetStructuredData returns nil, since RFC3164 syslog messages do not have structured data.
+func (m *SyslogMessage) GetStructuredData() *map[string]map[string]string {
+	return nil
 }
@@ -0,0 +1,26 @@
+package rfc3164
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessor(t *testing.T) {
+	m, err := NewMachine(WithYear(Year{YYYY: 2019})).Parse([]byte("<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8"))
+	assert.Nil(t, err)
+
+	a, ok := m.(syslog.Accessor)
+	assert.True(t, ok)
+	assert.Equal(t, "rfc3164", a.Format())
+	assert.Equal(t, uint8(34), *a.GetPriority())
+	assert.Equal(t, time.Date(2019, 10, 11, 22, 14, 15, 0, time.UTC), *a.GetTimestamp())
+	assert.Equal(t, "mymachine", *a.GetHostname())
+	assert.Equal(t, "su", *a.GetAppname())
+	assert.Equal(t, "123", *a.GetProcID())
+	assert.Nil(t, a.GetMsgID())
+	assert.Equal(t, "'su root' failed for lonvick on /dev/pts/8", *a.GetMessage())
+	assert.Nil(t, a.GetStructuredData())
+}
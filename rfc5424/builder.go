@@ -2,7 +2,7 @@ package rfc5424
 
 import (
 	"fmt"
-	"sort"
+	"strconv"
 	"time"
 
 	"github.com/influxdata/go-syslog/v3/common"
@@ -9348,64 +9348,52 @@ func (sm *SyslogMessage) SetMessage(value string) Builder {
 	return sm.set(msg, value)
 }
 
+// String returns the RFC5424 representation of the message.
+//
+// It only checks that the message is minimally valid (see Valid), use an Encoder to validate all its parts.
 func (sm *SyslogMessage) String() (string, error) {
-	if !sm.Valid() {
-		return "", fmt.Errorf("invalid syslog")
+	out, err := sm.AppendTo(nil)
+	if err != nil {
+		return "", err
 	}
 
-	template := "<%d>%d %s %s %s %s %s %s%s"
+	return string(out), nil
+}
 
-	t := "-"
-	hn := "-"
-	an := "-"
-	pid := "-"
-	mid := "-"
-	sd := "-"
-	m := ""
-	if sm.Timestamp != nil {
-		t = sm.Timestamp.Format("2006-01-02T15:04:05.999999Z07:00") // verify 07:00
-	}
-	if sm.Hostname != nil {
-		hn = *sm.Hostname
-	}
-	if sm.Appname != nil {
-		an = *sm.Appname
-	}
-	if sm.ProcID != nil {
-		pid = *sm.ProcID
+// AppendTo appends the RFC5424 representation of the message to dst and returns the extended buffer.
+//
+// It writes the same output of String. When dst has enough capacity it does not allocate,
+// except for sorting the IDs of the structured data elements and the names of their parameters.
+// When the message is not minimally valid it returns dst unchanged and an error.
+func (sm *SyslogMessage) AppendTo(dst []byte) ([]byte, error) {
+	if !sm.Valid() {
+		return dst, fmt.Errorf("invalid syslog")
 	}
-	if sm.MsgID != nil {
-		mid = *sm.MsgID
+
+	dst = append(dst, '<')
+	dst = strconv.AppendUint(dst, uint64(*sm.Priority), 10)
+	dst = append(dst, '>')
+	dst = strconv.AppendUint(dst, uint64(sm.Version), 10)
+	dst = append(dst, ' ')
+	if sm.Timestamp != nil {
+		dst = sm.Timestamp.AppendFormat(dst, "2006-01-02T15:04:05.999999Z07:00") // verify 07:00
+	} else {
+		dst = append(dst, '-')
 	}
+	dst = appendHeaderField(dst, sm.Hostname)
+	dst = appendHeaderField(dst, sm.Appname)
+	dst = appendHeaderField(dst, sm.ProcID)
+	dst = appendHeaderField(dst, sm.MsgID)
+	dst = append(dst, ' ')
 	if sm.StructuredData != nil {
-		// Sort element identifiers
-		identifiers := make([]string, 0)
-		for k := range *sm.StructuredData {
-			identifiers = append(identifiers, k)
-		}
-		sort.Strings(identifiers)
-
-		sd = ""
-		for _, id := range identifiers {
-			sd += fmt.Sprintf("[%s", id)
-
-			// Sort parameter names
-			params := (*sm.StructuredData)[id]
-			names := make([]string, 0)
-			for n := range params {
-				names = append(names, n)
-			}
-			sort.Strings(names)
-
-			for _, name := range names {
-				sd += fmt.Sprintf(" %s=\"%s\"", name, common.EscapeBytes(params[name]))
-			}
-			sd += "]"
-		}
+		dst = appendElements(dst, *sm.StructuredData)
+	} else {
+		dst = append(dst, '-')
 	}
 	if sm.Message != nil {
-		m = " " + *sm.Message
+		dst = append(dst, ' ')
+		dst = append(dst, *sm.Message...)
 	}
 
-	return fmt.Sprintf(template, *sm.Priority, sm.Version, t, hn, an, pid, mid, sd, m), nil
+	return dst, nil
 }
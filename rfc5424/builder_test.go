@@ -439,3 +439,30 @@ func TestSerialization(t *testing.T) {
 	assert.Empty(t, res)
 	assert.Error(t, err)
 }
+
+func TestAppendTo(t *testing.T) {
+	m := &SyslogMessage{}
+	m.SetPriority(1).SetVersion(1).SetParameter("sdid", "x", "⌘").SetMessage("κόσμε")
+
+	str, err := m.String()
+	assert.Nil(t, err)
+	assert.Equal(t, `<1>1 - - - - - [sdid x="⌘"] κόσμε`, str)
+
+	out, err := m.AppendTo([]byte("prefix "))
+	assert.Nil(t, err)
+	assert.Equal(t, "prefix "+str, string(out))
+
+	m2 := &SyslogMessage{}
+	out, err = m2.AppendTo([]byte("prefix "))
+	assert.Error(t, err)
+	assert.Equal(t, "prefix ", string(out))
+}
+
+func TestStringMultiByteParamValues(t *testing.T) {
+	m := &SyslogMessage{}
+	m.SetPriority(1).SetVersion(1).SetParameter("sdid", "x", "⌘ κόσμε")
+
+	str, err := m.String()
+	assert.Nil(t, err)
+	assert.Equal(t, `<1>1 - - - - - [sdid x="⌘ κόσμε"]`, str)
+}
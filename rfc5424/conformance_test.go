@@ -0,0 +1,32 @@
+package rfc5424
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3"
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the conformance tests")
+
+func TestConformance(t *testing.T) {
+	syslogtesting.Conformance(t, "testdata/conformance", *update, func(options []string) (syslog.Machine, error) {
+		opts := []syslog.MachineOption{}
+		for _, o := range options {
+			switch o {
+			case "best-effort":
+				opts = append(opts, WithBestEffort())
+			case "compliant-msg":
+				opts = append(opts, WithCompliantMsg())
+			case "lenient":
+				opts = append(opts, WithLenient())
+			default:
+				return nil, fmt.Errorf("unknown option %q", o)
+			}
+		}
+
+		return NewMachine(opts...), nil
+	})
+}
@@ -0,0 +1,234 @@
+package rfc5424
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/influxdata/go-syslog/v3/common"
+)
+
+// utf8BOM is the UTF-8 byte order mark.
+const utf8BOM = "\xEF\xBB\xBF"
+
+const (
+	// ErrTimestampPrecision represents an error for timestamps that cannot be written without losing precision.
+	ErrTimestampPrecision = "expecting a timestamp with at most microseconds precision"
+	// ErrTimestampRange represents an error for timestamps that the RFC3339 format of RFC5424 cannot represent.
+	ErrTimestampRange = "expecting a timestamp with year in the range 0-9999 and offset in the range -23:59 to +23:59, multiple of a minute"
+)
+
+// Encoder writes RFC5424 syslog messages to an output stream.
+//
+// Unlike the String method of SyslogMessage, it validates every part of the messages against the RFC5424 ABNF
+// before writing them, so that its output can be parsed back to the same message.
+// It does not add any framing (eg., trailing new lines or octet counts) to the messages.
+type Encoder struct {
+	w   io.Writer
+	bom bool
+	buf []byte
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// SetBOM specifies whether the encoder must start the MSG part with the UTF-8 byte order mark.
+//
+// RFC5424 section 6.4 mandates it for the messages encoded in UTF-8,
+// so when this is on the messages are also checked to be valid UTF-8.
+// The BOM is not written twice when the message already starts with it.
+func (e *Encoder) SetBOM(on bool) {
+	e.bom = on
+}
+
+// Encode writes the RFC5424 representation of m to the stream, with a single write.
+//
+// Nothing is written when the message is not valid.
+func (e *Encoder) Encode(m *SyslogMessage) error {
+	buf, err := e.Append(e.buf[:0], m)
+	if err != nil {
+		return err
+	}
+	e.buf = buf
+	_, err = e.w.Write(buf)
+
+	return err
+}
+
+// Append appends the RFC5424 representation of m to dst and returns the extended buffer.
+//
+// When the message is not valid it returns dst unchanged and the error.
+func (e *Encoder) Append(dst []byte, m *SyslogMessage) ([]byte, error) {
+	if err := e.validate(m); err != nil {
+		return dst, err
+	}
+
+	dst = append(dst, '<')
+	dst = strconv.AppendUint(dst, uint64(*m.Priority), 10)
+	dst = append(dst, '>')
+	dst = strconv.AppendUint(dst, uint64(m.Version), 10)
+	dst = append(dst, ' ')
+	if m.Timestamp != nil {
+		dst = m.Timestamp.AppendFormat(dst, RFC3339MICRO)
+	} else {
+		dst = append(dst, '-')
+	}
+	dst = appendHeaderField(dst, m.Hostname)
+	dst = appendHeaderField(dst, m.Appname)
+	dst = appendHeaderField(dst, m.ProcID)
+	dst = appendHeaderField(dst, m.MsgID)
+	dst = append(dst, ' ')
+	dst = appendStructuredData(dst, m.StructuredData)
+	// An empty message is parsed back as a missing one
+	if m.Message != nil && *m.Message != "" {
+		dst = append(dst, ' ')
+		if e.bom && !hasBOM(*m.Message) {
+			dst = append(dst, utf8BOM...)
+		}
+		dst = append(dst, *m.Message...)
+	}
+
+	return dst, nil
+}
+
+func (e *Encoder) validate(m *SyslogMessage) error {
+	if m.Priority == nil || !common.ValidPriority(*m.Priority) {
+		return errors.New(ErrPrival)
+	}
+	if !common.ValidVersion(m.Version) {
+		return fmt.Errorf(ErrVersion+" [got %d]", m.Version)
+	}
+	if m.Timestamp != nil {
+		if err := validateTimestamp(*m.Timestamp); err != nil {
+			return err
+		}
+	}
+	if m.Hostname != nil && !isHeaderField(*m.Hostname, 255) {
+		return fmt.Errorf(ErrHostname+" [got %q]", *m.Hostname)
+	}
+	if m.Appname != nil && !isHeaderField(*m.Appname, 48) {
+		return fmt.Errorf(ErrAppname+" [got %q]", *m.Appname)
+	}
+	if m.ProcID != nil && !isHeaderField(*m.ProcID, 128) {
+		return fmt.Errorf(ErrProcID+" [got %q]", *m.ProcID)
+	}
+	if m.MsgID != nil && !isHeaderField(*m.MsgID, 32) {
+		return fmt.Errorf(ErrMsgID+" [got %q]", *m.MsgID)
+	}
+	if m.StructuredData != nil {
+		for id, params := range *m.StructuredData {
+			if !isSdName(id) {
+				return fmt.Errorf(ErrSdID+" [got %q]", id)
+			}
+			for name, value := range params {
+				if !isSdName(name) || !utf8.ValidString(value) {
+					return fmt.Errorf(ErrSdParam+" [got %q=%q]", name, value)
+				}
+			}
+		}
+	}
+	if m.Message != nil && (e.bom || hasBOM(*m.Message)) && !utf8.ValidString(*m.Message) {
+		return errors.New(ErrMsg)
+	}
+
+	return nil
+}
+
+func validateTimestamp(t time.Time) error {
+	if t.Nanosecond()%int(time.Microsecond) != 0 {
+		return fmt.Errorf(ErrTimestampPrecision+" [got %s]", t.Format(time.RFC3339Nano))
+	}
+	_, offset := t.Zone()
+	if offset < 0 {
+		offset = -offset
+	}
+	if t.Year() < 0 || t.Year() > 9999 || offset%60 != 0 || offset > 23*3600+59*60 {
+		return fmt.Errorf(ErrTimestampRange+" [got %s]", t.Format(time.RFC3339Nano))
+	}
+
+	return nil
+}
+
+// appendHeaderField appends a space and the value, or the nil value when missing.
+func appendHeaderField(dst []byte, value *string) []byte {
+	dst = append(dst, ' ')
+	if value == nil {
+		return append(dst, '-')
+	}
+	return append(dst, *value...)
+}
+
+// appendStructuredData appends the structured data elements, or the nil value when there are none.
+func appendStructuredData(dst []byte, sd *map[string]map[string]string) []byte {
+	if sd == nil || len(*sd) == 0 {
+		return append(dst, '-')
+	}
+
+	return appendElements(dst, *sd)
+}
+
+// appendElements appends the elements sorted by ID, with their parameters sorted by name.
+func appendElements(dst []byte, sd map[string]map[string]string) []byte {
+	ids := make([]string, 0, len(sd))
+	for id := range sd {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		dst = append(dst, '[')
+		dst = append(dst, id...)
+
+		params := sd[id]
+		names := make([]string, 0, len(params))
+		for name := range params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			dst = append(dst, ' ')
+			dst = append(dst, name...)
+			dst = append(dst, '=', '"')
+			dst = common.AppendEscaped(dst, params[name])
+			dst = append(dst, '"')
+		}
+		dst = append(dst, ']')
+	}
+
+	return dst
+}
+
+// isHeaderField tells whether the input is made of 1 to limit printable US-ASCII characters.
+func isHeaderField(s string, limit int) bool {
+	if len(s) == 0 || len(s) > limit {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 33 || s[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+// isSdName tells whether the input is a valid SD-NAME (ie., a SD-ID or a PARAM-NAME).
+func isSdName(s string) bool {
+	if !isHeaderField(s, 32) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c == '=' || c == ']' || c == '"' {
+			return false
+		}
+	}
+	return true
+}
+
+func hasBOM(s string) bool {
+	return len(s) >= len(utf8BOM) && s[:len(utf8BOM)] == utf8BOM
+}
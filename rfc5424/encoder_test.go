@@ -0,0 +1,159 @@
+package rfc5424
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderRoundTrip(t *testing.T) {
+	cases := []string{
+		`<0>1 - - - - - -`,
+		`<165>4 2018-10-11T22:14:15.003Z mymach.it e - 1 [ex@32473 iut="3"] An application event log entry...`,
+		`<191>999 2003-10-11T22:14:15.000003+02:00 host app 1234 ID47 [a@1 k="v\"a\\l\]ue" z=""][b@1] message` + "\nwith new line",
+		`<14>1 - ` + string(syslogtesting.MaxHostname) + ` ` + string(syslogtesting.MaxAppname) + ` ` + string(syslogtesting.MaxProcID) + ` ` + string(syslogtesting.MaxMsgID) + ` -`,
+		"<14>1 - - - - - - " + BOM + "àèìòù",
+		"<14>1 - - - - - - \xff not UTF-8",
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(syslogtesting.RightPad(tc, 50), func(t *testing.T) {
+			t.Parallel()
+
+			m, err := NewParser(WithCompliantMsg()).Parse([]byte(tc))
+			assert.Nil(t, err)
+
+			var buf bytes.Buffer
+			assert.Nil(t, NewEncoder(&buf).Encode(m.(*SyslogMessage)))
+			assert.Equal(t, tc, buf.String())
+
+			again, err := NewParser(WithCompliantMsg()).Parse(buf.Bytes())
+			assert.Nil(t, err)
+			assert.Equal(t, m, again)
+		})
+	}
+}
+
+func TestEncoderAppend(t *testing.T) {
+	m := (&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").(*SyslogMessage)
+
+	out, err := NewEncoder(nil).Append([]byte("prefix "), m)
+	assert.Nil(t, err)
+	assert.Equal(t, "prefix <1>1 - host - - - -", string(out))
+
+	m.Version = 0
+	out, err = NewEncoder(nil).Append([]byte("prefix "), m)
+	assert.Error(t, err)
+	assert.Equal(t, "prefix ", string(out))
+}
+
+func TestEncoderBOM(t *testing.T) {
+	m := (&SyslogMessage{}).SetPriority(1).SetVersion(1).SetMessage("àèìòù").(*SyslogMessage)
+	e := NewEncoder(nil)
+	e.SetBOM(true)
+
+	out, err := e.Append(nil, m)
+	assert.Nil(t, err)
+	assert.Equal(t, "<1>1 - - - - - - "+BOM+"àèìòù", string(out))
+
+	// The parsed message contains the BOM
+	p, err := NewParser(WithCompliantMsg()).Parse(out)
+	assert.Nil(t, err)
+	assert.Equal(t, BOM+"àèìòù", *p.(*SyslogMessage).Message)
+
+	// No BOM is added to messages already starting with it
+	out, err = e.Append(nil, p.(*SyslogMessage))
+	assert.Nil(t, err)
+	assert.Equal(t, "<1>1 - - - - - - "+BOM+"àèìòù", string(out))
+
+	m.Message = syslogtesting.StringAddress("\xff")
+	_, err = e.Append(nil, m)
+	assert.EqualError(t, err, ErrMsg)
+}
+
+func TestEncoderValidation(t *testing.T) {
+	valid := func() *SyslogMessage {
+		return (&SyslogMessage{}).SetPriority(1).SetVersion(1).(*SyslogMessage)
+	}
+	sd := func(id, name, value string) *map[string]map[string]string {
+		return &map[string]map[string]string{id: {name: value}}
+	}
+	ts := func(t time.Time) *time.Time {
+		return &t
+	}
+
+	cases := []struct {
+		descr  string
+		change func(m *SyslogMessage)
+		err    string
+	}{
+		{"no priority", func(m *SyslogMessage) { m.Priority = nil }, ErrPrival},
+		{"priority", func(m *SyslogMessage) { m.ComputeFromPriority(192) }, ErrPrival},
+		{"version", func(m *SyslogMessage) { m.Version = 1000 }, ErrVersion + " [got 1000]"},
+		{
+			"timestamp precision",
+			func(m *SyslogMessage) { m.Timestamp = ts(time.Date(2003, 10, 11, 22, 14, 15, 1, time.UTC)) },
+			ErrTimestampPrecision + " [got 2003-10-11T22:14:15.000000001Z]",
+		},
+		{
+			"timestamp year",
+			func(m *SyslogMessage) { m.Timestamp = ts(time.Date(10000, 10, 11, 22, 14, 15, 0, time.UTC)) },
+			ErrTimestampRange + " [got 10000-10-11T22:14:15Z]",
+		},
+		{
+			"timestamp offset",
+			func(m *SyslogMessage) {
+				m.Timestamp = ts(time.Date(2003, 10, 11, 22, 14, 15, 0, time.FixedZone("", 30)))
+			},
+			ErrTimestampRange + " [got 2003-10-11T22:14:15+00:00]",
+		},
+		{"empty hostname", func(m *SyslogMessage) { m.Hostname = syslogtesting.StringAddress("") }, ErrHostname + ` [got ""]`},
+		{
+			"hostname length",
+			func(m *SyslogMessage) { m.Hostname = syslogtesting.StringAddress(strings.Repeat("a", 256)) },
+			ErrHostname + fmt.Sprintf(" [got %q]", strings.Repeat("a", 256)),
+		},
+		{"appname with spaces", func(m *SyslogMessage) { m.Appname = syslogtesting.StringAddress("a b") }, ErrAppname + ` [got "a b"]`},
+		{"procid", func(m *SyslogMessage) { m.ProcID = syslogtesting.StringAddress("à") }, ErrProcID + ` [got "à"]`},
+		{
+			"msgid length",
+			func(m *SyslogMessage) { m.MsgID = syslogtesting.StringAddress(strings.Repeat("a", 33)) },
+			ErrMsgID + fmt.Sprintf(" [got %q]", strings.Repeat("a", 33)),
+		},
+		{"sd-id", func(m *SyslogMessage) { m.StructuredData = sd("a=b", "k", "v") }, ErrSdID + ` [got "a=b"]`},
+		{"param name", func(m *SyslogMessage) { m.StructuredData = sd("a@1", `k"`, "v") }, ErrSdParam + ` [got "k\""="v"]`},
+		{"param value", func(m *SyslogMessage) { m.StructuredData = sd("a@1", "k", "\xff") }, ErrSdParam + ` [got "k"="\xff"]`},
+		{"message", func(m *SyslogMessage) { m.Message = syslogtesting.StringAddress(BOM + "\xff") }, ErrMsg},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.descr, func(t *testing.T) {
+			t.Parallel()
+
+			m := valid()
+			tc.change(m)
+			var buf bytes.Buffer
+			assert.EqualError(t, NewEncoder(&buf).Encode(m), tc.err)
+			assert.Equal(t, 0, buf.Len())
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failure")
+}
+
+func TestEncoderWriteError(t *testing.T) {
+	m := (&SyslogMessage{}).SetPriority(1).SetVersion(1).(*SyslogMessage)
+	assert.EqualError(t, NewEncoder(failingWriter{}).Encode(m), "write failure")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/influxdata/go-syslog/v3"
 )
 
 func output(out interface{}) {
@@ -39,7 +40,8 @@ func Example() {
 	//   (string) (len=8) "ex@32473": (map[string]string) (len=1) {
 	//    (string) (len=3) "iut": (string) (len=1) "3"
 	//   }
-	//  })
+	//  }),
+	//  Raw: (*rfc5424.Raw)(<nil>)
 	// })
 	// An application event log entry...
 	// mymach.it
@@ -70,7 +72,8 @@ func Example_besteffort() {
 	//   Message: (*string)(<nil>)
 	//  },
 	//  Version: (uint16) 1,
-	//  StructuredData: (*map[string]map[string]string)(<nil>)
+	//  StructuredData: (*map[string]map[string]string)(<nil>),
+	//  Raw: (*rfc5424.Raw)(<nil>)
 	// })
 	// expecting a RFC3339MICRO timestamp or a nil value [col 5]
 }
@@ -101,7 +104,41 @@ func Example_builder() {
 	//   Message: (*string)(<nil>)
 	//  },
 	//  Version: (uint16) 1,
-	//  StructuredData: (*map[string]map[string]string)(<nil>)
+	//  StructuredData: (*map[string]map[string]string)(<nil>),
+	//  Raw: (*rfc5424.Raw)(<nil>)
 	// })
 	// <191>1 - - - - - -
 }
+
+func Example_lenient() {
+	i := []byte(`<1>1 2003-13-11T22:14:15.003Z host.local app 1234 ID47 [a@1 k="v" z=v] msg`)
+	p := NewParser(WithLenient())
+	m, e := p.Parse(i)
+	output(m)
+	for _, err := range e.(syslog.Errors) {
+		fmt.Println(err)
+	}
+	// Output:
+	// (*rfc5424.SyslogMessage)({
+	//  Base: (syslog.Base) {
+	//   Facility: (*uint8)(0),
+	//   Severity: (*uint8)(1),
+	//   Priority: (*uint8)(1),
+	//   Timestamp: (*time.Time)(<nil>),
+	//   Hostname: (*string)((len=10) "host.local"),
+	//   Appname: (*string)((len=3) "app"),
+	//   ProcID: (*string)((len=4) "1234"),
+	//   MsgID: (*string)((len=4) "ID47"),
+	//   Message: (*string)((len=3) "msg")
+	//  },
+	//  Version: (uint16) 1,
+	//  StructuredData: (*map[string]map[string]string)((len=1) {
+	//   (string) (len=3) "a@1": (map[string]string) (len=1) {
+	//    (string) (len=1) "k": (string) (len=1) "v"
+	//   }
+	//  }),
+	//  Raw: (*rfc5424.Raw)(<nil>)
+	// })
+	// expecting a RFC3339MICRO timestamp or a nil value [col 11]
+	// expecting a structured data parameter (`key="value"`, both part from 1 to max 32 US-ASCII characters; key cannot contain `=`, ` `, `]`, and `"`, while value cannot contain `]`, backslash, and `"` unless escaped) [col 68]
+}
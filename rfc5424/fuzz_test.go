@@ -0,0 +1,139 @@
+//go:build go1.18
+// +build go1.18
+
+package rfc5424
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+var fuzzOptions = [][]syslog.MachineOption{
+	nil,
+	{WithBestEffort()},
+	{WithCompliantMsg()},
+	{WithLenient()},
+	{WithBestEffort(), WithRaw()},
+	{WithSdIDValidation(nil)},
+}
+
+// equal tells whether the messages are the same, regardless of the location of their timestamps and of their raw input.
+func equal(a, b *SyslogMessage) bool {
+	x, y := *a, *b
+	x.Raw, y.Raw = nil, nil
+	if (x.Timestamp == nil) != (y.Timestamp == nil) {
+		return false
+	}
+	if x.Timestamp != nil {
+		_, xoff := x.Timestamp.Zone()
+		_, yoff := y.Timestamp.Zone()
+		if !x.Timestamp.Equal(*y.Timestamp) || xoff != yoff {
+			return false
+		}
+		x.Timestamp, y.Timestamp = nil, nil
+	}
+
+	return reflect.DeepEqual(x, y)
+}
+
+// checkSpan fails when the span is not within the input.
+func checkSpan(t *testing.T, part string, s syslog.Span, input []byte) {
+	t.Helper()
+	if s.Start < 0 || s.End < s.Start || s.End > len(input) {
+		t.Fatalf("%s span %v out of the input (%d octets)", part, s, len(input))
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, tc := range testCases {
+		f.Add(tc.input)
+	}
+	for _, tc := range nonCompliantMsgTestCases {
+		f.Add(tc.input)
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		for _, opts := range fuzzOptions {
+			m, err := NewMachine(opts...).Parse(input)
+			if m == nil {
+				if err == nil {
+					t.Fatal("neither a message nor an error")
+				}
+				continue
+			}
+			msg := m.(*SyslogMessage)
+			if raw := msg.Raw; raw != nil {
+				for part, s := range map[string]syslog.Span{
+					"priority": raw.Priority, "version": raw.Version, "timestamp": raw.Timestamp,
+					"hostname": raw.Hostname, "appname": raw.Appname, "procid": raw.ProcID, "msgid": raw.MsgID, "message": raw.Message,
+				} {
+					checkSpan(t, part, s, raw.Input)
+				}
+				for _, e := range raw.Elements {
+					checkSpan(t, "element", e.Span, raw.Input)
+					for _, p := range e.Params {
+						checkSpan(t, "param", p.Span, raw.Input)
+						checkSpan(t, "value", p.Value, raw.Input)
+					}
+				}
+			}
+			if err != nil {
+				continue
+			}
+
+			// Valid messages survive a round trip
+			if !msg.Valid() {
+				t.Fatalf("invalid message parsed without errors from %q", input)
+			}
+			out, err := msg.String()
+			if err != nil {
+				t.Fatalf("serializing the message parsed from %q: %v", input, err)
+			}
+			again, err := NewMachine(opts...).Parse([]byte(out))
+			if err != nil {
+				t.Fatalf("parsing %q, serialized from %q: %v", out, input, err)
+			}
+			if !equal(msg, again.(*SyslogMessage)) {
+				t.Fatalf("round trip of %q through %q changed the message", input, out)
+			}
+		}
+	})
+}
+
+func FuzzBuilder(f *testing.F) {
+	f.Add(uint8(165), uint16(1), "2003-10-11T22:14:15.003Z", "mymachine.example.com", "evntslog", "-", "ID47", "exampleSDID@32473", "iut", "3", "An application event log entry...")
+	f.Add(uint8(0), uint16(999), "1985-04-12T19:20:50.52-04:00", "192.0.2.1", "myproc", "8710", "-", "origin", "ip", `a"b\c]`, "%% It's time to make the do-nuts.")
+	f.Add(uint8(191), uint16(2), "", "", "", "", "", "", "", "", "")
+
+	f.Fuzz(func(t *testing.T, priority uint8, version uint16, timestamp, hostname, appname, procid, msgid, id, name, value, message string) {
+		m := &SyslogMessage{}
+		m.SetPriority(priority).
+			SetVersion(version).
+			SetTimestamp(timestamp).
+			SetHostname(hostname).
+			SetAppname(appname).
+			SetProcID(procid).
+			SetMsgID(msgid).
+			SetElementID(id).
+			SetParameter(id, name, value).
+			SetMessage(message)
+		if !m.Valid() {
+			return
+		}
+
+		// Valid builder output can be parsed back into the same message
+		out, err := m.String()
+		if err != nil {
+			t.Fatalf("serializing a valid message: %v", err)
+		}
+		parsed, err := NewMachine().Parse([]byte(out))
+		if err != nil {
+			t.Fatalf("parsing %q: %v", out, err)
+		}
+		if !equal(m, parsed.(*SyslogMessage)) {
+			t.Fatalf("parsing %q gives a different message", out)
+		}
+	})
+}
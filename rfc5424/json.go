@@ -0,0 +1,52 @@
+package rfc5424
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+type jsonMessage struct {
+	Format  string `json:"format"`
+	Version uint16 `json:"version"`
+	syslog.BaseJSON
+	StructuredData *map[string]map[string]string `json:"structured_data,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The resulting object contains the "format" key (always "rfc5424"), the "version" key,
+// the keys of syslog.BaseJSON, and the "structured_data" key mapping the element IDs to their parameters.
+// The Raw field is not part of it.
+func (sm *SyslogMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMessage{
+		Format:         Format,
+		Version:        sm.Version,
+		BaseJSON:       sm.Base.JSON(),
+		StructuredData: sm.StructuredData,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It accepts the objects produced by MarshalJSON.
+// The "format" key can be omitted, otherwise it must be "rfc5424".
+func (sm *SyslogMessage) UnmarshalJSON(data []byte) error {
+	var j jsonMessage
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Format != "" && j.Format != Format {
+		return fmt.Errorf("expecting format %q, got %q", Format, j.Format)
+	}
+
+	*sm = SyslogMessage{}
+	if err := sm.Base.FromJSON(j.BaseJSON); err != nil {
+		return err
+	}
+	sm.Version = j.Version
+	sm.StructuredData = j.StructuredData
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package rfc5424
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	m := (&SyslogMessage{}).
+		SetPriority(165).
+		SetVersion(4).
+		SetTimestamp("2018-10-11T22:14:15.003Z").
+		SetHostname("mymach.it").
+		SetAppname("e").
+		SetMsgID("1").
+		SetParameter("ex@32473", "iut", "3").
+		SetMessage("An application event log entry...")
+
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"format": "rfc5424",
+		"version": 4,
+		"priority": 165,
+		"facility": 20,
+		"facility_keyword": "local4",
+		"severity": 5,
+		"severity_keyword": "notice",
+		"timestamp": "2018-10-11T22:14:15.003Z",
+		"hostname": "mymach.it",
+		"appname": "e",
+		"msgid": "1",
+		"structured_data": {"ex@32473": {"iut": "3"}},
+		"message": "An application event log entry..."
+	}`, string(data))
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	cases := []Builder{
+		(&SyslogMessage{}).SetPriority(0).SetVersion(1),
+		(&SyslogMessage{}).SetPriority(191).SetVersion(999).SetTimestamp("2003-10-11T22:14:15.000003+02:00"),
+		(&SyslogMessage{}).SetPriority(34).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetMessage("message\nwith new line"),
+		(&SyslogMessage{}).SetPriority(14).SetVersion(1).SetElementID("empty@1").SetParameter("a@1", "k", `va"l\u]e`).SetParameter("a@1", "z", "").SetParameter("b@1", "k", "v"),
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		str, _ := tc.(*SyslogMessage).String()
+		t.Run(str, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(tc)
+			assert.Nil(t, err)
+
+			got := &SyslogMessage{}
+			assert.Nil(t, json.Unmarshal(data, got))
+			assert.Equal(t, tc, got)
+			again, _ := got.String()
+			assert.Equal(t, str, again)
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	got := &SyslogMessage{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"version": 1, "facility": 20, "severity": 5, "hostname": "host"}`), got))
+	assert.Equal(t, (&SyslogMessage{}).SetPriority(165).SetVersion(1).SetHostname("host"), got)
+
+	// Derived values are ignored when the priority is present
+	assert.Nil(t, json.Unmarshal([]byte(`{"version": 1, "priority": 1, "facility": 20, "severity_keyword": "notice"}`), got))
+	assert.Equal(t, (&SyslogMessage{}).SetPriority(1).SetVersion(1), got)
+
+	assert.EqualError(t, json.Unmarshal([]byte(`{"format": "rfc3164", "priority": 1}`), got), `expecting format "rfc5424", got "rfc3164"`)
+	assert.EqualError(t, json.Unmarshal([]byte(`{"priority": 192}`), got), "invalid priority 192")
+	assert.EqualError(t, json.Unmarshal([]byte(`{"facility": 24, "severity": 0}`), got), "invalid facility 24 or severity 0")
+}
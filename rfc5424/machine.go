@@ -34,6 +34,8 @@ const (
 	ErrSdID = "expecting a structured data element id (from 1 to max 32 US-ASCII characters; except `=`, ` `, `]`, and `\"`"
 	// ErrSdIDDuplicated represents an error occurring when two STRUCTURED DATA elementes have the same ID in a RFC5424 syslog message.
 	ErrSdIDDuplicated = "duplicate structured data element id"
+	// ErrSdIDUnregistered represents an error occurring when a STRUCTURED DATA element ID is neither registered nor followed by @ and a private enterprise number.
+	ErrSdIDUnregistered = "expecting a registered structured data element id or an id in the name@<private enterprise number> format"
 	// ErrSdParam represents an error regarding a STRUCTURED DATA PARAM of the RFC5424 syslog message.
 	ErrSdParam = "expecting a structured data parameter (`key=\"value\"`, both part from 1 to max 32 US-ASCII characters; key cannot contain `=`, ` `, `]`, and `\"`, while value cannot contain `]`, backslash, and `\"` unless escaped)"
 	// ErrMsg represents an error in the MESSAGE part of the RFC5424 syslog message.
@@ -69,6 +71,10 @@ type machine struct {
 	backslashat  []int
 	bestEffort   bool
 	compliantMsg bool
+	raw          bool
+	lenient      bool
+	errs         []error
+	sdIDs        SdIDRegistry
 }
 
 // NewMachine creates a new FSM able to parse RFC5424 syslog messages.
@@ -99,10 +105,35 @@ func (m *machine) Err() error {
 	return m.err
 }
 
+// skipTo collects the current error and moves the machine onto the next occurrence of c, if any.
+//
+// A closing square bracket only counts when it can end a structured data element,
+// that is when it is not escaped and it is followed by a space, by another element, or by nothing.
+//
+// It tells whether the parsing can go on from there.
+func (m *machine) skipTo(c byte) bool {
+	for i := m.p + 1; i < m.pe; i++ {
+		if m.data[i] != c {
+			continue
+		}
+		if c == ']' && (m.data[i-1] == '\\' || (i+1 < m.pe && m.data[i+1] != ' ' && m.data[i+1] != '[')) {
+			continue
+		}
+		m.errs = append(m.errs, m.err)
+		m.p = i
+		return true
+	}
+	return false
+}
+
 func (m *machine) text() []byte {
 	return m.data[m.pb:m.p]
 }
 
+func (m *machine) span() syslog.Span {
+	return syslog.Span{Start: m.pb, End: m.p}
+}
+
 // Parse parses the input byte array as a RFC5424 syslog message.
 //
 // When a valid RFC5424 syslog message is given it outputs its structured representation.
@@ -119,7 +150,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	m.pe = len(input)
 	m.eof = len(input)
 	m.err = nil
+	m.errs = nil
 	output := &syslogMessage{}
+	if m.raw {
+		output.raw = &Raw{Input: append([]byte(nil), input...)}
+	}
 
 	{
 		m.cs = start
@@ -1372,6 +1407,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrPri+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st6
+			}
+		}
 		{
 			goto st614
 		}
@@ -1382,6 +1422,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrPrival+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st6
+			}
+		}
 		{
 			goto st614
 		}
@@ -1389,6 +1434,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrPri+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st6
+			}
+		}
 		{
 			goto st614
 		}
@@ -1406,6 +1456,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrVersion+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st6
+			}
+		}
 		{
 			goto st614
 		}
@@ -1433,6 +1488,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrTimestamp+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st8
+			}
+		}
 		{
 			goto st614
 		}
@@ -1450,6 +1510,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrHostname+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st10
+			}
+		}
 		{
 			goto st614
 		}
@@ -1467,6 +1532,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrAppname+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st12
+			}
+		}
 		{
 			goto st614
 		}
@@ -1484,6 +1554,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrProcID+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st14
+			}
+		}
 		{
 			goto st614
 		}
@@ -1501,6 +1576,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrMsgID+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st16
+			}
+		}
 		{
 			goto st614
 		}
@@ -1518,6 +1598,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrMsgID+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			{
+				goto st16
+			}
+		}
 		{
 			goto st614
 		}
@@ -1528,6 +1613,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			(m.p)--
+
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1536,12 +1628,18 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	tr36:
 
 		delete(output.structuredData, m.currentelem)
+		output.raw.removeElement(m.currentelem)
 		if len(output.structuredData) == 0 {
 			output.hasElements = false
 		}
 		m.err = fmt.Errorf(ErrSdID+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(']') {
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1549,6 +1647,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			(m.p)--
+
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1561,6 +1666,24 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			m.err = fmt.Errorf(ErrSdIDDuplicated+ColumnPositionTemplate, m.p)
 			(m.p)--
 
+			if m.lenient && m.skipTo(']') {
+				{
+					goto st606
+				}
+			}
+			{
+				goto st614
+			}
+		} else if m.sdIDs != nil && !validSdID(m.sdIDs, string(m.text())) {
+			// As per RFC5424 section 6.3.2 SD-ID MUST be a registered name or contain the @ followed by a private enterprise number
+			m.err = fmt.Errorf(ErrSdIDUnregistered+ColumnPositionTemplate, m.p)
+			(m.p)--
+
+			if m.lenient && m.skipTo(']') {
+				{
+					goto st606
+				}
+			}
 			{
 				goto st614
 			}
@@ -1569,15 +1692,22 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			output.structuredData[id] = map[string]string{}
 			output.hasElements = true
 			m.currentelem = id
+			output.raw.addElement(id, m.pb-1, m.p)
 		}
 
 		delete(output.structuredData, m.currentelem)
+		output.raw.removeElement(m.currentelem)
 		if len(output.structuredData) == 0 {
 			output.hasElements = false
 		}
 		m.err = fmt.Errorf(ErrSdID+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(']') {
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1585,6 +1715,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			(m.p)--
+
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1594,10 +1731,16 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 
 		if len(output.structuredData) > 0 {
 			delete(output.structuredData[m.currentelem], m.currentparam)
+			output.raw.removeParam(m.currentparam)
 		}
 		m.err = fmt.Errorf(ErrSdParam+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(']') {
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1605,6 +1748,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			(m.p)--
+
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1615,16 +1765,27 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrEscape+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(']') {
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
 
 		if len(output.structuredData) > 0 {
 			delete(output.structuredData[m.currentelem], m.currentparam)
+			output.raw.removeParam(m.currentparam)
 		}
 		m.err = fmt.Errorf(ErrSdParam+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(']') {
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1632,6 +1793,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			(m.p)--
+
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1643,12 +1811,20 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			m.err = fmt.Errorf("%s [col %d]", e, m.p)
 			(m.p)--
 
+			if m.lenient && m.skipTo(' ') {
+				{
+					goto st8
+				}
+			}
 			{
 				goto st614
 			}
 		} else {
 			output.timestamp = t
 			output.timestampSet = true
+			if output.raw != nil {
+				output.raw.Timestamp = m.span()
+			}
 		}
 
 		m.err = fmt.Errorf(ErrParse+ColumnPositionTemplate, m.p)
@@ -1665,6 +1841,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		if m.msgat > 0 {
 			// Save the text until valid (m.p is where the parser has stopped)
 			output.message = string(m.data[m.msgat:m.p])
+			if output.raw != nil {
+				output.raw.Message = syslog.Span{Start: m.msgat, End: m.p}
+			}
 		}
 
 		if m.compliantMsg {
@@ -1685,6 +1864,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 		(m.p)--
 
+		if m.lenient && m.skipTo(' ') {
+			(m.p)--
+
+			{
+				goto st606
+			}
+		}
 		{
 			goto st614
 		}
@@ -1729,6 +1915,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 
 		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
 		output.prioritySet = true
+		if output.raw != nil {
+			output.raw.Priority = m.span()
+		}
 
 		if (m.data)[(m.p)] == 62 {
 			goto st4
@@ -1755,6 +1944,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	stCase5:
 
 		output.version = uint16(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
+		if output.raw != nil {
+			output.raw.Version = m.span()
+		}
 
 		if (m.data)[(m.p)] == 32 {
 			goto st6
@@ -1790,12 +1982,20 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			m.err = fmt.Errorf("%s [col %d]", e, m.p)
 			(m.p)--
 
+			if m.lenient && m.skipTo(' ') {
+				{
+					goto st8
+				}
+			}
 			{
 				goto st614
 			}
 		} else {
 			output.timestamp = t
 			output.timestampSet = true
+			if output.raw != nil {
+				output.raw.Timestamp = m.span()
+			}
 		}
 
 		goto st8
@@ -1828,6 +2028,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	tr18:
 
 		output.hostname = string(m.text())
+		if output.raw != nil {
+			output.raw.Hostname = m.span()
+		}
 
 		goto st10
 	st10:
@@ -1859,6 +2062,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	tr22:
 
 		output.appname = string(m.text())
+		if output.raw != nil {
+			output.raw.Appname = m.span()
+		}
 
 		goto st12
 	st12:
@@ -1890,6 +2096,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	tr26:
 
 		output.procID = string(m.text())
+		if output.raw != nil {
+			output.raw.ProcID = m.span()
+		}
 
 		goto st14
 	st14:
@@ -1921,6 +2130,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	tr31:
 
 		output.msgID = string(m.text())
+		if output.raw != nil {
+			output.raw.MsgID = m.span()
+		}
 
 		goto st16
 	st16:
@@ -2030,6 +2242,24 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			m.err = fmt.Errorf(ErrSdIDDuplicated+ColumnPositionTemplate, m.p)
 			(m.p)--
 
+			if m.lenient && m.skipTo(']') {
+				{
+					goto st606
+				}
+			}
+			{
+				goto st614
+			}
+		} else if m.sdIDs != nil && !validSdID(m.sdIDs, string(m.text())) {
+			// As per RFC5424 section 6.3.2 SD-ID MUST be a registered name or contain the @ followed by a private enterprise number
+			m.err = fmt.Errorf(ErrSdIDUnregistered+ColumnPositionTemplate, m.p)
+			(m.p)--
+
+			if m.lenient && m.skipTo(']') {
+				{
+					goto st606
+				}
+			}
 			{
 				goto st614
 			}
@@ -2038,6 +2268,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			output.structuredData[id] = map[string]string{}
 			output.hasElements = true
 			m.currentelem = id
+			output.raw.addElement(id, m.pb-1, m.p)
 		}
 
 		goto st19
@@ -2820,6 +3051,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				text = common.RemoveBytes(text, m.backslashat, m.pb)
 			}
 			output.structuredData[m.currentelem][m.currentparam] = string(text)
+			output.raw.setParam(m.currentparam, m.pb, m.p)
 		}
 
 		goto st55
@@ -2836,6 +3068,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				text = common.RemoveBytes(text, m.backslashat, m.pb)
 			}
 			output.structuredData[m.currentelem][m.currentparam] = string(text)
+			output.raw.setParam(m.currentparam, m.pb, m.p)
 		}
 
 		goto st55
@@ -2858,6 +3091,24 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			m.err = fmt.Errorf(ErrSdIDDuplicated+ColumnPositionTemplate, m.p)
 			(m.p)--
 
+			if m.lenient && m.skipTo(']') {
+				{
+					goto st606
+				}
+			}
+			{
+				goto st614
+			}
+		} else if m.sdIDs != nil && !validSdID(m.sdIDs, string(m.text())) {
+			// As per RFC5424 section 6.3.2 SD-ID MUST be a registered name or contain the @ followed by a private enterprise number
+			m.err = fmt.Errorf(ErrSdIDUnregistered+ColumnPositionTemplate, m.p)
+			(m.p)--
+
+			if m.lenient && m.skipTo(']') {
+				{
+					goto st606
+				}
+			}
 			{
 				goto st614
 			}
@@ -2866,6 +3117,7 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			output.structuredData[id] = map[string]string{}
 			output.hasElements = true
 			m.currentelem = id
+			output.raw.addElement(id, m.pb-1, m.p)
 		}
 
 		goto st606
@@ -9583,6 +9835,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	stCase591:
 
 		output.version = uint16(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
+		if output.raw != nil {
+			output.raw.Version = m.span()
+		}
 
 		if (m.data)[(m.p)] == 32 {
 			goto st6
@@ -9598,6 +9853,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	stCase592:
 
 		output.version = uint16(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
+		if output.raw != nil {
+			output.raw.Version = m.span()
+		}
 
 		if (m.data)[(m.p)] == 32 {
 			goto st6
@@ -9616,6 +9874,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 
 		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
 		output.prioritySet = true
+		if output.raw != nil {
+			output.raw.Priority = m.span()
+		}
 
 		switch (m.data)[(m.p)] {
 		case 57:
@@ -9640,6 +9901,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 
 		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
 		output.prioritySet = true
+		if output.raw != nil {
+			output.raw.Priority = m.span()
+		}
 
 		if (m.data)[(m.p)] == 62 {
 			goto st4
@@ -9656,6 +9920,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 
 		output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
 		output.prioritySet = true
+		if output.raw != nil {
+			output.raw.Priority = m.span()
+		}
 
 		if (m.data)[(m.p)] == 62 {
 			goto st4
@@ -11692,12 +11959,20 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			case 608, 610, 611, 612, 613:
 
 				output.message = string(m.text())
+				if output.raw != nil {
+					output.raw.Message = m.span()
+				}
 
 			case 1:
 
 				m.err = fmt.Errorf(ErrPri+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st6
+					}
+				}
 				{
 					goto st614
 				}
@@ -11707,6 +11982,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrMsgID+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st16
+					}
+				}
 				{
 					goto st614
 				}
@@ -11716,6 +11996,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					(m.p)--
+
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
@@ -11726,6 +12013,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				if m.msgat > 0 {
 					// Save the text until valid (m.p is where the parser has stopped)
 					output.message = string(m.data[m.msgat:m.p])
+					if output.raw != nil {
+						output.raw.Message = syslog.Span{Start: m.msgat, End: m.p}
+					}
 				}
 
 				if m.compliantMsg {
@@ -11752,6 +12042,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			case 5:
 
 				output.version = uint16(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
+				if output.raw != nil {
+					output.raw.Version = m.span()
+				}
 
 				m.err = fmt.Errorf(ErrParse+ColumnPositionTemplate, m.p)
 				(m.p)--
@@ -11766,12 +12059,20 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 					m.err = fmt.Errorf("%s [col %d]", e, m.p)
 					(m.p)--
 
+					if m.lenient && m.skipTo(' ') {
+						{
+							goto st8
+						}
+					}
 					{
 						goto st614
 					}
 				} else {
 					output.timestamp = t
 					output.timestampSet = true
+					if output.raw != nil {
+						output.raw.Timestamp = m.span()
+					}
 				}
 
 				m.err = fmt.Errorf(ErrParse+ColumnPositionTemplate, m.p)
@@ -11786,6 +12087,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrVersion+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st6
+					}
+				}
 				{
 					goto st614
 				}
@@ -11802,6 +12108,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrTimestamp+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st8
+					}
+				}
 				{
 					goto st614
 				}
@@ -11818,6 +12129,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrHostname+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st10
+					}
+				}
 				{
 					goto st614
 				}
@@ -11834,6 +12150,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrAppname+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st12
+					}
+				}
 				{
 					goto st614
 				}
@@ -11850,6 +12171,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrProcID+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st14
+					}
+				}
 				{
 					goto st614
 				}
@@ -11866,6 +12192,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrMsgID+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st16
+					}
+				}
 				{
 					goto st614
 				}
@@ -11880,12 +12211,18 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 			case 17:
 
 				delete(output.structuredData, m.currentelem)
+				output.raw.removeElement(m.currentelem)
 				if len(output.structuredData) == 0 {
 					output.hasElements = false
 				}
 				m.err = fmt.Errorf(ErrSdID+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(']') {
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
@@ -11893,6 +12230,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					(m.p)--
+
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
@@ -11901,10 +12245,16 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 
 				if len(output.structuredData) > 0 {
 					delete(output.structuredData[m.currentelem], m.currentparam)
+					output.raw.removeParam(m.currentparam)
 				}
 				m.err = fmt.Errorf(ErrSdParam+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(']') {
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
@@ -11912,6 +12262,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					(m.p)--
+
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
@@ -11923,6 +12280,9 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.msgat = m.p
 
 				output.message = string(m.text())
+				if output.raw != nil {
+					output.raw.Message = m.span()
+				}
 
 			case 18, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94:
 
@@ -11931,6 +12291,24 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 					m.err = fmt.Errorf(ErrSdIDDuplicated+ColumnPositionTemplate, m.p)
 					(m.p)--
 
+					if m.lenient && m.skipTo(']') {
+						{
+							goto st606
+						}
+					}
+					{
+						goto st614
+					}
+				} else if m.sdIDs != nil && !validSdID(m.sdIDs, string(m.text())) {
+					// As per RFC5424 section 6.3.2 SD-ID MUST be a registered name or contain the @ followed by a private enterprise number
+					m.err = fmt.Errorf(ErrSdIDUnregistered+ColumnPositionTemplate, m.p)
+					(m.p)--
+
+					if m.lenient && m.skipTo(']') {
+						{
+							goto st606
+						}
+					}
 					{
 						goto st614
 					}
@@ -11939,15 +12317,22 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 					output.structuredData[id] = map[string]string{}
 					output.hasElements = true
 					m.currentelem = id
+					output.raw.addElement(id, m.pb-1, m.p)
 				}
 
 				delete(output.structuredData, m.currentelem)
+				output.raw.removeElement(m.currentelem)
 				if len(output.structuredData) == 0 {
 					output.hasElements = false
 				}
 				m.err = fmt.Errorf(ErrSdID+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(']') {
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
@@ -11955,6 +12340,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					(m.p)--
+
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
@@ -11964,6 +12356,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrPrival+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st6
+					}
+				}
 				{
 					goto st614
 				}
@@ -11971,6 +12368,11 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrPri+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st6
+					}
+				}
 				{
 					goto st614
 				}
@@ -11987,11 +12389,19 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrVersion+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					{
+						goto st6
+					}
+				}
 				{
 					goto st614
 				}
 
 				output.version = uint16(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
+				if output.raw != nil {
+					output.raw.Version = m.span()
+				}
 
 				m.err = fmt.Errorf(ErrParse+ColumnPositionTemplate, m.p)
 				(m.p)--
@@ -12005,16 +12415,27 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrEscape+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(']') {
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
 
 				if len(output.structuredData) > 0 {
 					delete(output.structuredData[m.currentelem], m.currentparam)
+					output.raw.removeParam(m.currentparam)
 				}
 				m.err = fmt.Errorf(ErrSdParam+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(']') {
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
@@ -12022,6 +12443,13 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 				m.err = fmt.Errorf(ErrStructuredData+ColumnPositionTemplate, m.p)
 				(m.p)--
 
+				if m.lenient && m.skipTo(' ') {
+					(m.p)--
+
+					{
+						goto st606
+					}
+				}
 				{
 					goto st614
 				}
@@ -12034,7 +12462,17 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 		}
 	}
 
-	if m.cs < firstFinal || m.cs == enFail {
+	failed := m.cs < firstFinal || m.cs == enFail
+	// In lenient mode report all the errors that have been encountered
+	if m.lenient && (failed || len(m.errs) > 0) {
+		if failed {
+			m.errs = append(m.errs, m.err)
+		}
+		m.err = syslog.Errors(m.errs)
+		failed = true
+	}
+
+	if failed {
 		if m.bestEffort && output.minimal() {
 			// An error occurred but partial parsing is on and partial message is minimally valid
 			return output.export(), m.err
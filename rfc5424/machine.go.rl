@@ -34,6 +34,8 @@ const (
 	ErrSdID            = "expecting a structured data element id (from 1 to max 32 US-ASCII characters; except `=`, ` `, `]`, and `\"`"
 	// ErrSdIDDuplicated represents an error occurring when two STRUCTURED DATA elementes have the same ID in a RFC5424 syslog message.
 	ErrSdIDDuplicated  = "duplicate structured data element id"
+	// ErrSdIDUnregistered represents an error occurring when a STRUCTURED DATA element ID is neither registered nor followed by @ and a private enterprise number.
+	ErrSdIDUnregistered = "expecting a registered structured data element id or an id in the name@<private enterprise number> format"
 	// ErrSdParam represents an error regarding a STRUCTURED DATA PARAM of the RFC5424 syslog message.
 	ErrSdParam         = "expecting a structured data parameter (`key=\"value\"`, both part from 1 to max 32 US-ASCII characters; key cannot contain `=`, ` `, `]`, and `\"`, while value cannot contain `]`, backslash, and `\"` unless escaped)"
 	// ErrMsg represents an error in the MESSAGE part of the RFC5424 syslog message.
@@ -77,37 +79,61 @@ action select_msg_mode {
 action set_prival {
 	output.priority = uint8(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
 	output.prioritySet = true
+	if output.raw != nil {
+		output.raw.Priority = m.span()
+	}
 }
 
 action set_version {
 	output.version = uint16(common.UnsafeUTF8DecimalCodePointsToInt(m.text()))
+	if output.raw != nil {
+		output.raw.Version = m.span()
+	}
 }
 
 action set_timestamp {
 	if t, e := time.Parse(RFC3339MICRO, string(m.text())); e != nil {
 		m.err = fmt.Errorf("%s [col %d]", e, m.p)
 		fhold;
+		if m.lenient && m.skipTo(' ') {
+			fgoto at_hostname;
+		}
 		fgoto fail;
 	} else {
 		output.timestamp = t
 		output.timestampSet = true
+		if output.raw != nil {
+			output.raw.Timestamp = m.span()
+		}
 	}
 }
 
 action set_hostname {
 	output.hostname = string(m.text())
+	if output.raw != nil {
+		output.raw.Hostname = m.span()
+	}
 }
 
 action set_appname {
 	output.appname = string(m.text())
+	if output.raw != nil {
+		output.raw.Appname = m.span()
+	}
 }
 
 action set_procid {
 	output.procID = string(m.text())
+	if output.raw != nil {
+		output.raw.ProcID = m.span()
+	}
 }
 
 action set_msgid {
 	output.msgID = string(m.text())
+	if output.raw != nil {
+		output.raw.MsgID = m.span()
+	}
 }
 
 action ini_elements {
@@ -119,12 +145,24 @@ action set_id {
 		// As per RFC5424 section 6.3.2 SD-ID MUST NOT exist more than once in a message
 		m.err = fmt.Errorf(ErrSdIDDuplicated + ColumnPositionTemplate, m.p)
 		fhold;
+		if m.lenient && m.skipTo(']') {
+			fgoto at_elements;
+		}
+		fgoto fail;
+	} else if m.sdIDs != nil && !validSdID(m.sdIDs, string(m.text())) {
+		// As per RFC5424 section 6.3.2 SD-ID MUST be a registered name or contain the @ followed by a private enterprise number
+		m.err = fmt.Errorf(ErrSdIDUnregistered + ColumnPositionTemplate, m.p)
+		fhold;
+		if m.lenient && m.skipTo(']') {
+			fgoto at_elements;
+		}
 		fgoto fail;
 	} else {
 		id := string(m.text())
 		output.structuredData[id] = map[string]string{}
 		output.hasElements = true
 		m.currentelem = id
+		output.raw.addElement(id, m.pb-1, m.p)
 	}
 }
 
@@ -152,83 +190,123 @@ action set_paramvalue {
 			text = common.RemoveBytes(text, m.backslashat, m.pb)
 		}
 		output.structuredData[m.currentelem][m.currentparam] = string(text)
+		output.raw.setParam(m.currentparam, m.pb, m.p)
 	}
 }
 
 action set_msg {
 	output.message = string(m.text())
+	if output.raw != nil {
+		output.raw.Message = m.span()
+	}
 }
 
 action err_prival {
 	m.err = fmt.Errorf(ErrPrival + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(' ') {
+		fgoto at_timestamp;
+	}
 	fgoto fail;
 }
 
 action err_pri {
 	m.err = fmt.Errorf(ErrPri + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(' ') {
+		fgoto at_timestamp;
+	}
 	fgoto fail;
 }
 
 action err_version {
 	m.err = fmt.Errorf(ErrVersion + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(' ') {
+		fgoto at_timestamp;
+	}
 	fgoto fail;
 }
 
 action err_timestamp {
 	m.err = fmt.Errorf(ErrTimestamp + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(' ') {
+		fgoto at_hostname;
+	}
 	fgoto fail;
 }
 
 action err_hostname {
 	m.err = fmt.Errorf(ErrHostname + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(' ') {
+		fgoto at_appname;
+	}
 	fgoto fail;
 }
 
 action err_appname {
 	m.err = fmt.Errorf(ErrAppname + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(' ') {
+		fgoto at_procid;
+	}
 	fgoto fail;
 }
 
 action err_procid {
 	m.err = fmt.Errorf(ErrProcID + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(' ') {
+		fgoto at_msgid;
+	}
 	fgoto fail;
 }
 
 action err_msgid {
 	m.err = fmt.Errorf(ErrMsgID + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(' ') {
+		fgoto at_structureddata;
+	}
 	fgoto fail;
 }
 
 action err_structureddata {
 	m.err = fmt.Errorf(ErrStructuredData + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(' ') {
+		fhold;
+		fgoto at_elements;
+	}
 	fgoto fail;
 }
 
 action err_sdid {
 	delete(output.structuredData, m.currentelem)
+	output.raw.removeElement(m.currentelem)
 	if len(output.structuredData) == 0 {
 		output.hasElements = false
 	}
 	m.err = fmt.Errorf(ErrSdID + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(']') {
+		fgoto at_elements;
+	}
 	fgoto fail;
 }
 
 action err_sdparam {
 	if len(output.structuredData) > 0 {
 		delete(output.structuredData[m.currentelem], m.currentparam)
+		output.raw.removeParam(m.currentparam)
 	}
 	m.err = fmt.Errorf(ErrSdParam + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(']') {
+		fgoto at_elements;
+	}
 	fgoto fail;
 }
 
@@ -237,6 +315,9 @@ action err_msg {
 	if m.msgat > 0 {
 		// Save the text until valid (m.p is where the parser has stopped)
 		output.message = string(m.data[m.msgat:m.p])
+		if output.raw != nil {
+			output.raw.Message = syslog.Span{Start: m.msgat, End: m.p}
+		}
 	}
 
 	if m.compliantMsg {
@@ -252,6 +333,9 @@ action err_msg {
 action err_escape {
 	m.err = fmt.Errorf(ErrEscape + ColumnPositionTemplate, m.p)
 	fhold;
+	if m.lenient && m.skipTo(']') {
+		fgoto at_elements;
+	}
 	fgoto fail;
 }
 
@@ -277,7 +361,7 @@ procid = procidrange >mark %set_procid $err(err_procid);
 
 msgid = msgidrange >mark %set_msgid $err(err_msgid);
 
-header = (pri version sp timestamp sp hostname sp appname sp procid sp msgid) <>err(err_parse);
+header = (pri version sp at_timestamp: timestamp sp at_hostname: hostname sp at_appname: appname sp at_procid: procid sp at_msgid: msgid) <>err(err_parse);
 
 # \", \], \\
 escapes = (bs >add_slash toescape) $err(err_escape);
@@ -296,7 +380,7 @@ sdid = sdname >mark %set_id %err(set_id) $err(err_sdid);
 
 sdelement = ('[' sdid (sp sdparam)* ']');
 
-structureddata = nilvalue | sdelement+ >ini_elements $err(err_structureddata);
+structureddata = nilvalue | (sdelement at_elements: sdelement*) >ini_elements $err(err_structureddata);
 
 msg_any := any* >mark >markmsg %set_msg $err(err_msg);
 
@@ -309,7 +393,7 @@ msg = any? @select_msg_mode;
 
 fail := (any - [\n\r])* @err{ fgoto main; };
 
-main := header sp structureddata (sp msg)? $err(err_parse);
+main := header sp at_structureddata: structureddata (sp msg)? $err(err_parse);
 
 }%%
 
@@ -327,6 +411,10 @@ type machine struct {
 	backslashat  []int
 	bestEffort 	 bool
 	compliantMsg bool
+	raw          bool
+	lenient      bool
+	errs         []error
+	sdIDs        SdIDRegistry
 }
 
 // NewMachine creates a new FSM able to parse RFC5424 syslog messages.
@@ -363,10 +451,35 @@ func (m *machine) Err() error {
 	return m.err
 }
 
+// skipTo collects the current error and moves the machine onto the next occurrence of c, if any.
+//
+// A closing square bracket only counts when it can end a structured data element,
+// that is when it is not escaped and it is followed by a space, by another element, or by nothing.
+//
+// It tells whether the parsing can go on from there.
+func (m *machine) skipTo(c byte) bool {
+	for i := m.p + 1; i < m.pe; i++ {
+		if m.data[i] != c {
+			continue
+		}
+		if c == ']' && (m.data[i-1] == '\\' || (i+1 < m.pe && m.data[i+1] != ' ' && m.data[i+1] != '[')) {
+			continue
+		}
+		m.errs = append(m.errs, m.err)
+		m.p = i
+		return true
+	}
+	return false
+}
+
 func (m *machine) text() []byte {
 	return m.data[m.pb:m.p]
 }
 
+func (m *machine) span() syslog.Span {
+	return syslog.Span{Start: m.pb, End: m.p}
+}
+
 // Parse parses the input byte array as a RFC5424 syslog message.
 //
 // When a valid RFC5424 syslog message is given it outputs its structured representation.
@@ -383,12 +496,26 @@ func (m *machine) Parse(input []byte) (syslog.Message, error) {
 	m.pe = len(input)
 	m.eof = len(input)
 	m.err = nil
+	m.errs = nil
 	output := &syslogMessage{}
+	if m.raw {
+		output.raw = &Raw{Input: append([]byte(nil), input...)}
+	}
 
 	%% write init;
 	%% write exec;
 
-	if m.cs < first_final || m.cs == en_fail {
+	failed := m.cs < first_final || m.cs == en_fail
+	// In lenient mode report all the errors that have been encountered
+	if m.lenient && (failed || len(m.errs) > 0) {
+		if failed {
+			m.errs = append(m.errs, m.err)
+		}
+		m.err = syslog.Errors(m.errs)
+		failed = true
+	}
+
+	if failed {
 		if m.bestEffort && output.minimal() {
 			// An error occurred but partial parsing is on and partial message is minimally valid
 			return output.export(), m.err
@@ -1741,3 +1741,320 @@ func isoLatin1String(s string) string {
 
 	return latin1
 }
+
+func TestMachineParseWithRaw(t *testing.T) {
+	input := []byte(`<165>4 2018-10-11T22:14:15.003Z mymach.it e - 1 [ex@32473 iut="3" eventSource="Appl\"ication"][x@1] An application event log entry...`)
+	message, err := NewMachine(WithRaw()).Parse(input)
+	assert.Nil(t, err)
+
+	raw := message.(*SyslogMessage).Raw
+	assert.NotNil(t, raw)
+	assert.Equal(t, input, raw.Input)
+	text := func(s syslog.Span) string {
+		return string(raw.Input[s.Start:s.End])
+	}
+	assert.Equal(t, "165", text(raw.Priority))
+	assert.Equal(t, "4", text(raw.Version))
+	assert.Equal(t, "2018-10-11T22:14:15.003Z", text(raw.Timestamp))
+	assert.Equal(t, "mymach.it", text(raw.Hostname))
+	assert.Equal(t, "e", text(raw.Appname))
+	assert.Equal(t, "-", text(raw.ProcID))
+	assert.Equal(t, "1", text(raw.MsgID))
+	assert.Len(t, raw.Elements, 2)
+	assert.Equal(t, "ex@32473", raw.Elements[0].ID)
+	assert.Equal(t, `[ex@32473 iut="3" eventSource="Appl\"ication"]`, text(raw.Elements[0].Span))
+	assert.Len(t, raw.Elements[0].Params, 2)
+	assert.Equal(t, "iut", raw.Elements[0].Params[0].Name)
+	assert.Equal(t, `iut="3"`, text(raw.Elements[0].Params[0].Span))
+	assert.Equal(t, "3", text(raw.Elements[0].Params[0].Value))
+	assert.Equal(t, "eventSource", raw.Elements[0].Params[1].Name)
+	assert.Equal(t, `eventSource="Appl\"ication"`, text(raw.Elements[0].Params[1].Span))
+	assert.Equal(t, `Appl\"ication`, text(raw.Elements[0].Params[1].Value))
+	assert.Equal(t, "x@1", raw.Elements[1].ID)
+	assert.Equal(t, "[x@1]", text(raw.Elements[1].Span))
+	assert.Empty(t, raw.Elements[1].Params)
+	assert.Equal(t, "An application event log entry...", text(raw.Message))
+
+	// The input is copied
+	input[len(input)-1] = '!'
+	assert.Equal(t, "An application event log entry...", text(raw.Message))
+
+	// Without the option nothing is recorded
+	message, err = NewMachine().Parse(input)
+	assert.Nil(t, err)
+	assert.Nil(t, message.(*SyslogMessage).Raw)
+}
+
+func TestMachineParseWithRawBestEffort(t *testing.T) {
+	input := []byte(`<1>1 - host app - - [a@1 k="v"][b@1 k="v" z=0] msg`)
+	message, err := NewMachine(WithRaw(), WithBestEffort()).Parse(input)
+	assert.EqualError(t, err, fmt.Sprintf(ErrSdParam+ColumnPositionTemplate, 44))
+
+	raw := message.(*SyslogMessage).Raw
+	text := func(s syslog.Span) string {
+		return string(raw.Input[s.Start:s.End])
+	}
+	assert.Equal(t, "host", text(raw.Hostname))
+	assert.Equal(t, "app", text(raw.Appname))
+	assert.Equal(t, syslog.Span{}, raw.Message)
+	// Elements and params mirror the partial structured data
+	assert.Equal(t, map[string]map[string]string{"a@1": {"k": "v"}, "b@1": {"k": "v"}}, *message.(*SyslogMessage).StructuredData)
+	assert.Len(t, raw.Elements, 2)
+	assert.Equal(t, `[a@1 k="v"]`, text(raw.Elements[0].Span))
+	assert.Equal(t, `[b@1 k="v"`, text(raw.Elements[1].Span))
+	assert.Len(t, raw.Elements[1].Params, 1)
+}
+
+func TestMachineParseWithRawConsistency(t *testing.T) {
+	text := func(raw *Raw, s syslog.Span) *string {
+		if s == (syslog.Span{}) {
+			return nil
+		}
+		str := string(raw.Input[s.Start:s.End])
+		if str == "-" {
+			return nil
+		}
+		return &str
+	}
+
+	for _, tc := range testCases {
+		message, _ := NewMachine(WithRaw(), WithBestEffort()).Parse(tc.input)
+		if message == nil {
+			continue
+		}
+		msg := message.(*SyslogMessage)
+		assert.Equal(t, tc.input, msg.Raw.Input)
+		assert.Equal(t, msg.Hostname, text(msg.Raw, msg.Raw.Hostname))
+		assert.Equal(t, msg.Appname, text(msg.Raw, msg.Raw.Appname))
+		assert.Equal(t, msg.ProcID, text(msg.Raw, msg.Raw.ProcID))
+		assert.Equal(t, msg.MsgID, text(msg.Raw, msg.Raw.MsgID))
+		if msg.Message != nil {
+			assert.Equal(t, msg.Message, text(msg.Raw, msg.Raw.Message))
+		}
+		if msg.StructuredData != nil {
+			assert.Len(t, msg.Raw.Elements, len(*msg.StructuredData))
+			for _, e := range msg.Raw.Elements {
+				assert.Contains(t, *msg.StructuredData, e.ID)
+				assert.Len(t, e.Params, len((*msg.StructuredData)[e.ID]))
+			}
+		}
+
+		// Recording the raw input does not change the parsing outcome
+		msg.Raw = nil
+		partial, _ := NewMachine(WithBestEffort()).Parse(tc.input)
+		assert.Equal(t, partial, message)
+	}
+}
+
+func TestMachineLenientOption(t *testing.T) {
+	m := NewMachine(WithLenient())
+	assert.True(t, m.HasBestEffort())
+	assert.True(t, m.(*machine).lenient)
+}
+
+func TestMachineParseLenientSameAsStrict(t *testing.T) {
+	// Valid messages are not affected by the lenient mode
+	for _, tc := range testCases {
+		if !tc.valid {
+			continue
+		}
+		message, err := NewMachine(WithLenient()).Parse(tc.input)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.value, message)
+	}
+}
+
+func TestMachineParseLenient(t *testing.T) {
+	cases := []struct {
+		descr  string
+		input  string
+		errors []string
+		value  syslog.Message
+	}{
+		{
+			"pri",
+			`<1000>1 - host app 1234 ID47 - msg`,
+			[]string{fmt.Sprintf(ErrPrival+ColumnPositionTemplate, 4)},
+			nil,
+		},
+		{
+			"version",
+			`<1>0 - host app 1234 ID47 - msg`,
+			[]string{fmt.Sprintf(ErrVersion+ColumnPositionTemplate, 3)},
+			nil,
+		},
+		{
+			"timestamp",
+			`<1>1 2003-13-11T22:14:15.003Z host app 1234 ID47 - msg`,
+			[]string{fmt.Sprintf(ErrTimestamp+ColumnPositionTemplate, 11)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetMessage("msg"),
+		},
+		{
+			"timestamp out of range",
+			`<1>1 2003-02-30T22:14:15.003Z host app 1234 ID47 - msg`,
+			[]string{`parsing time "2003-02-30T22:14:15.003Z": day out of range [col 29]`},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetMessage("msg"),
+		},
+		{
+			"hostname",
+			"<1>1 - ho\x00st app 1234 ID47 - msg",
+			[]string{fmt.Sprintf(ErrHostname+ColumnPositionTemplate, 9)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetMessage("msg"),
+		},
+		{
+			"appname",
+			`<1>1 - host ` + string(syslogtesting.MaxAppname) + `X 1234 ID47 [a@1 k="v"] msg`,
+			[]string{fmt.Sprintf(ErrAppname+ColumnPositionTemplate, 60)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetProcID("1234").SetMsgID("ID47").SetParameter("a@1", "k", "v").SetMessage("msg"),
+		},
+		{
+			"procid",
+			`<1>1 - host app ` + string(syslogtesting.MaxProcID) + `X ID47 - msg`,
+			[]string{fmt.Sprintf(ErrProcID+ColumnPositionTemplate, 144)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetMsgID("ID47").SetMessage("msg"),
+		},
+		{
+			"msgid",
+			`<1>1 - host app 1234 ` + string(syslogtesting.MaxMsgID) + `X - msg`,
+			[]string{fmt.Sprintf(ErrMsgID+ColumnPositionTemplate, 53)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMessage("msg"),
+		},
+		{
+			"empty parts",
+			`<1>1 -  app 1234  - msg`,
+			[]string{
+				fmt.Sprintf(ErrHostname+ColumnPositionTemplate, 7),
+				fmt.Sprintf(ErrMsgID+ColumnPositionTemplate, 17),
+			},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetAppname("app").SetProcID("1234").SetMessage("msg"),
+		},
+		{
+			"structured data",
+			`<1>1 - host app 1234 ID47 x msg`,
+			[]string{fmt.Sprintf(ErrStructuredData+ColumnPositionTemplate, 26)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetMessage("msg"),
+		},
+		{
+			"structured data after elements",
+			`<1>1 - host app 1234 ID47 [a@1 k="v"]x msg`,
+			[]string{fmt.Sprintf(ErrStructuredData+ColumnPositionTemplate, 37)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetParameter("a@1", "k", "v").SetMessage("msg"),
+		},
+		{
+			"element id",
+			`<1>1 - host app 1234 ID47 [a@1 k="v"][b=1][c@1 k="v"] msg`,
+			[]string{fmt.Sprintf(ErrSdID+ColumnPositionTemplate, 39)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetParameter("a@1", "k", "v").SetParameter("c@1", "k", "v").SetMessage("msg"),
+		},
+		{
+			"duplicated element id",
+			`<1>1 - host app 1234 ID47 [a@1 k="v"][a@1 z="v"][c@1] msg`,
+			[]string{fmt.Sprintf(ErrSdIDDuplicated+ColumnPositionTemplate, 41)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetParameter("a@1", "k", "v").SetElementID("c@1").SetMessage("msg"),
+		},
+		{
+			"param",
+			`<1>1 - host app 1234 ID47 [a@1 k="v" z=v][c@1 k="v"] msg`,
+			[]string{fmt.Sprintf(ErrSdParam+ColumnPositionTemplate, 39)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetParameter("a@1", "k", "v").SetParameter("c@1", "k", "v").SetMessage("msg"),
+		},
+		{
+			"escape",
+			`<1>1 - host app 1234 ID47 [a@1 k="v" z="x]y" w="\]"][c@1 k="v"] msg`,
+			[]string{fmt.Sprintf(ErrEscape+ColumnPositionTemplate, 41)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetParameter("a@1", "k", "v").SetParameter("c@1", "k", "v").SetMessage("msg"),
+		},
+		{
+			"many",
+			`<1>1 2003-13-11T22:14:15.003Z host ` + string(syslogtesting.MaxAppname) + `X 1234 ID47 [a@1 k="v" z=v][c@1 k="v"] msg`,
+			[]string{
+				fmt.Sprintf(ErrTimestamp+ColumnPositionTemplate, 11),
+				fmt.Sprintf(ErrAppname+ColumnPositionTemplate, 83),
+				fmt.Sprintf(ErrSdParam+ColumnPositionTemplate, 108),
+			},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetProcID("1234").SetMsgID("ID47").SetParameter("a@1", "k", "v").SetParameter("c@1", "k", "v").SetMessage("msg"),
+		},
+		{
+			"unrecoverable",
+			`<1>1 - host app 1234 ID47 [a@1 k="v" z=v msg`,
+			[]string{fmt.Sprintf(ErrSdParam+ColumnPositionTemplate, 39)},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetHostname("host").SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetParameter("a@1", "k", "v"),
+		},
+		{
+			"unrecoverable after recovering",
+			"<1>1 - ho\x00st app 1234 ID47 [a@1 k=\"v\" z=v msg",
+			[]string{
+				fmt.Sprintf(ErrHostname+ColumnPositionTemplate, 9),
+				fmt.Sprintf(ErrSdParam+ColumnPositionTemplate, 40),
+			},
+			(&SyslogMessage{}).SetPriority(1).SetVersion(1).SetAppname("app").SetProcID("1234").SetMsgID("ID47").SetParameter("a@1", "k", "v"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.descr, func(t *testing.T) {
+			t.Parallel()
+
+			message, err := NewMachine(WithLenient()).Parse([]byte(tc.input))
+			assert.Equal(t, tc.value, message)
+
+			errs, ok := err.(syslog.Errors)
+			if assert.True(t, ok) && assert.Len(t, errs, len(tc.errors)) {
+				for i, e := range errs {
+					assert.EqualError(t, e, tc.errors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMachineSdIDValidationOption(t *testing.T) {
+	m := NewMachine(WithSdIDValidation(nil))
+	assert.Equal(t, IANASdIDs, m.(*machine).sdIDs)
+
+	m = NewMachine(WithSdIDValidation(SdIDs{"x"}))
+	assert.Equal(t, SdIDs{"x"}, m.(*machine).sdIDs)
+}
+
+func TestMachineParseSdIDValidation(t *testing.T) {
+	cases := []struct {
+		input    string
+		registry SdIDRegistry
+		err      string
+	}{
+		{`<1>1 - - - - - [timeQuality tzKnown="1"][meta][origin]`, nil, ""},
+		{`<1>1 - - - - - [exampleSDID@32473 iut="3"]`, nil, ""},
+		{`<1>1 - - - - - [custom x="1"]`, SdIDs{"custom"}, ""},
+		{`<1>1 - - - - - [custom x="1"]`, nil, fmt.Sprintf(ErrSdIDUnregistered+ColumnPositionTemplate, 22)},
+		{`<1>1 - - - - - [meta][timequality]`, nil, fmt.Sprintf(ErrSdIDUnregistered+ColumnPositionTemplate, 33)},
+		{`<1>1 - - - - - [meta x="1"]`, SdIDs{"custom"}, fmt.Sprintf(ErrSdIDUnregistered+ColumnPositionTemplate, 20)},
+		{`<1>1 - - - - - [example@abc]`, nil, fmt.Sprintf(ErrSdIDUnregistered+ColumnPositionTemplate, 27)},
+		{`<1>1 - - - - - [@32473]`, nil, fmt.Sprintf(ErrSdIDUnregistered+ColumnPositionTemplate, 22)},
+		{`<1>1 - - - - - [example@]`, nil, fmt.Sprintf(ErrSdIDUnregistered+ColumnPositionTemplate, 24)},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewMachine(WithSdIDValidation(tc.registry)).Parse([]byte(tc.input))
+			if tc.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			// Without the option any SD-ID is accepted
+			_, err = NewMachine().Parse([]byte(tc.input))
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestMachineParseSdIDValidationLenient(t *testing.T) {
+	message, err := NewMachine(WithLenient(), WithSdIDValidation(nil)).Parse([]byte(`<1>1 - - - - - [custom x="1"][meta sequenceId="1"] message`))
+	assert.Equal(t, syslog.Errors{fmt.Errorf(ErrSdIDUnregistered+ColumnPositionTemplate, 22)}, err)
+	assert.Equal(t, (&SyslogMessage{}).SetPriority(1).SetVersion(1).SetParameter("meta", "sequenceId", "1").SetMessage("message"), message)
+}
@@ -27,3 +27,45 @@ func WithCompliantMsg() syslog.MachineOption {
 		return m
 	}
 }
+
+// WithRaw enables the recording of the raw input and of the positions of the parts of the RFC5424 syslog messages.
+//
+// The parsed messages will carry them in their Raw field,
+// so that tooling can highlight or re-slice the input exactly, also when best effort mode returns partial messages.
+func WithRaw() syslog.MachineOption {
+	return func(m syslog.Machine) syslog.Machine {
+		m.(*machine).raw = true
+		return m
+	}
+}
+
+// WithLenient enables the lenient mode, which implies the best effort mode.
+//
+// When this is on, the parsing does not stop at the first error.
+// A malformed header part is skipped up to the next space, a malformed structured data element up to its closing square bracket,
+// and the parsing goes on with the following parts, so that the valid ones are still returned.
+// The resulting error is a syslog.Errors containing all the errors that have been encountered.
+func WithLenient() syslog.MachineOption {
+	return func(m syslog.Machine) syslog.Machine {
+		m.WithBestEffort()
+		m.(*machine).lenient = true
+		return m
+	}
+}
+
+// WithSdIDValidation enables the validation of the structured data element IDs.
+//
+// When this is on, the IDs not containing the @ character must be in the given registry (IANASdIDs when nil),
+// while the other ones must be in the name@<private enterprise number> format (eg., exampleSDID@32473).
+// Otherwise the parsing fails with an ErrSdIDUnregistered error.
+//
+// Ref.: https://tools.ietf.org/html/rfc5424#section-6.3.2
+func WithSdIDValidation(registry SdIDRegistry) syslog.MachineOption {
+	return func(m syslog.Machine) syslog.Machine {
+		if registry == nil {
+			registry = IANASdIDs
+		}
+		m.(*machine).sdIDs = registry
+		return m
+	}
+}
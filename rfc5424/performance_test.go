@@ -1,6 +1,8 @@
 package rfc5424
 
 import (
+	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/influxdata/go-syslog/v3"
@@ -12,6 +14,10 @@ import (
 // during benchmarks
 var benchParseResult syslog.Message
 
+var benchStringResult string
+
+var benchAppendResult []byte
+
 type benchCase struct {
 	input []byte
 	label string
@@ -119,3 +125,131 @@ func BenchmarkParseCompliantMessage(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkString(b *testing.B) {
+	for _, tc := range benchCases {
+		tc := tc
+		m, err := NewMachine().Parse(tc.input)
+		if err != nil {
+			continue
+		}
+		b.Run(syslogtesting.RightPad(tc.label, 50), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchStringResult, _ = m.(*SyslogMessage).String()
+			}
+		})
+	}
+}
+
+// BenchmarkStringBaseline measures the fmt-based String that AppendTo replaced, to compare them.
+func BenchmarkStringBaseline(b *testing.B) {
+	for _, tc := range benchCases {
+		tc := tc
+		m, err := NewMachine().Parse(tc.input)
+		if err != nil {
+			continue
+		}
+		b.Run(syslogtesting.RightPad(tc.label, 50), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchStringResult, _ = stringBaseline(m.(*SyslogMessage))
+			}
+		})
+	}
+}
+
+func BenchmarkAppendTo(b *testing.B) {
+	for _, tc := range benchCases {
+		tc := tc
+		m, err := NewMachine().Parse(tc.input)
+		if err != nil {
+			continue
+		}
+		b.Run(syslogtesting.RightPad(tc.label, 50), func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 1024)
+			for i := 0; i < b.N; i++ {
+				benchAppendResult, _ = m.(*SyslogMessage).AppendTo(buf[:0])
+			}
+		})
+	}
+}
+
+// stringBaseline is the implementation of String preceding AppendTo, only kept as a benchmark baseline.
+func stringBaseline(sm *SyslogMessage) (string, error) {
+	if !sm.Valid() {
+		return "", fmt.Errorf("invalid syslog")
+	}
+
+	template := "<%d>%d %s %s %s %s %s %s%s"
+
+	t := "-"
+	hn := "-"
+	an := "-"
+	pid := "-"
+	mid := "-"
+	sd := "-"
+	m := ""
+	if sm.Timestamp != nil {
+		t = sm.Timestamp.Format("2006-01-02T15:04:05.999999Z07:00") // verify 07:00
+	}
+	if sm.Hostname != nil {
+		hn = *sm.Hostname
+	}
+	if sm.Appname != nil {
+		an = *sm.Appname
+	}
+	if sm.ProcID != nil {
+		pid = *sm.ProcID
+	}
+	if sm.MsgID != nil {
+		mid = *sm.MsgID
+	}
+	if sm.StructuredData != nil {
+		// Sort element identifiers
+		identifiers := make([]string, 0)
+		for k := range *sm.StructuredData {
+			identifiers = append(identifiers, k)
+		}
+		sort.Strings(identifiers)
+
+		sd = ""
+		for _, id := range identifiers {
+			sd += fmt.Sprintf("[%s", id)
+
+			// Sort parameter names
+			params := (*sm.StructuredData)[id]
+			names := make([]string, 0)
+			for n := range params {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				sd += fmt.Sprintf(" %s=\"%s\"", name, escapeBaseline(params[name]))
+			}
+			sd += "]"
+		}
+	}
+	if sm.Message != nil {
+		m = " " + *sm.Message
+	}
+
+	return fmt.Sprintf(template, *sm.Priority, sm.Version, t, hn, an, pid, mid, sd, m), nil
+}
+
+// escapeBaseline is the implementation of common.EscapeBytes preceding common.AppendEscaped.
+//
+// It is kept as it was, thus it still mangles the multi-byte characters (appending their bytes as runes).
+func escapeBaseline(value string) string {
+	res := ""
+	for i, c := range value {
+		if c == 92 || c == 93 || c == 34 {
+			res += `\`
+		}
+		res += string(value[i])
+	}
+
+	return res
+}
@@ -0,0 +1,105 @@
+package rfc5424
+
+import (
+	"github.com/influxdata/go-syslog/v3"
+)
+
+// Raw contains the input a RFC5424 syslog message has been parsed from, together with the positions of its parts.
+//
+// Parts that have not been parsed have a zero Span.
+type Raw struct {
+	Input     []byte
+	Priority  syslog.Span
+	Version   syslog.Span
+	Timestamp syslog.Span
+	Hostname  syslog.Span
+	Appname   syslog.Span
+	ProcID    syslog.Span
+	MsgID     syslog.Span
+	Elements  []RawElement
+	Message   syslog.Span
+}
+
+// RawElement contains the positions of a STRUCTURED DATA element (SD-ELEMENT).
+//
+// Its Span includes the square brackets.
+type RawElement struct {
+	ID     string
+	Span   syslog.Span
+	Params []RawParam
+}
+
+// RawParam contains the positions of a STRUCTURED DATA parameter (SD-PARAM).
+//
+// Its Span includes both the name and the quoted value, while Value only includes the (escaped) value.
+type RawParam struct {
+	Name  string
+	Span  syslog.Span
+	Value syslog.Span
+}
+
+func (r *Raw) addElement(id string, start, end int) {
+	if r == nil {
+		return
+	}
+	r.Elements = append(r.Elements, RawElement{ID: id, Span: syslog.Span{Start: start, End: end}})
+}
+
+func (r *Raw) removeElement(id string) {
+	if r == nil {
+		return
+	}
+	for i := len(r.Elements) - 1; i >= 0; i-- {
+		if r.Elements[i].ID == id {
+			r.Elements = append(r.Elements[:i], r.Elements[i+1:]...)
+			return
+		}
+	}
+}
+
+// setParam records a SD-PARAM given the position of its value,
+// replacing the one with the same name in the current element, if any.
+func (r *Raw) setParam(name string, start, end int) {
+	if r == nil || len(r.Elements) == 0 {
+		return
+	}
+	p := RawParam{
+		Name: name,
+		// The name, the equal sign, and the double quotes surround the value
+		Span:  syslog.Span{Start: start - len(name) - 2, End: end + 1},
+		Value: syslog.Span{Start: start, End: end},
+	}
+	e := &r.Elements[len(r.Elements)-1]
+	e.Span.End = p.Span.End
+	for i := range e.Params {
+		if e.Params[i].Name == name {
+			e.Params[i] = p
+			return
+		}
+	}
+	e.Params = append(e.Params, p)
+}
+
+func (r *Raw) removeParam(name string) {
+	if r == nil || len(r.Elements) == 0 {
+		return
+	}
+	e := &r.Elements[len(r.Elements)-1]
+	for i := len(e.Params) - 1; i >= 0; i-- {
+		if e.Params[i].Name == name {
+			e.Params = append(e.Params[:i], e.Params[i+1:]...)
+		}
+	}
+}
+
+// close makes the spans of the elements include their closing square bracket.
+func (r *Raw) close() {
+	if r == nil {
+		return
+	}
+	for i := range r.Elements {
+		if end := r.Elements[i].Span.End; end < len(r.Input) && r.Input[end] == ']' {
+			r.Elements[i].Span.End++
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package rfc5424
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// IDs of the structured data elements registered with IANA (RFC5424 section 7, and RFC5848).
+const (
+	TimeQualityID = "timeQuality"
+	OriginID      = "origin"
+	MetaID        = "meta"
+	SSignID       = "ssign"      // The signature blocks (see the rfc5848 package)
+	SSignCertID   = "ssign-cert" // The certificate blocks (see the rfc5848 package)
+)
+
+// SdIDRegistry tells which structured data element IDs not containing the @ character are registered.
+type SdIDRegistry interface {
+	Registered(id string) bool
+}
+
+// SdIDs is a SdIDRegistry containing the given IDs.
+type SdIDs []string
+
+// Registered tells whether the id is among the receiving ones.
+func (ids SdIDs) Registered(id string) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IANASdIDs contains the structured data element IDs registered with IANA (RFC5424 section 9.2, and RFC5848).
+var IANASdIDs = SdIDs{TimeQualityID, OriginID, MetaID, SSignID, SSignCertID}
+
+const (
+	// ErrTimeQuality represents an error in the parameters of the timeQuality element.
+	ErrTimeQuality = "expecting timeQuality parameters tzKnown and isSynced to be 0 or 1, and syncAccuracy to be a number only when isSynced is not 0"
+	// ErrOrigin represents an error in the parameters of the origin element.
+	ErrOrigin = "expecting origin parameters ip to be an IP address, enterpriseId to be a private enterprise number, software and swVersion to be at most 48 and 32 characters"
+	// ErrMeta represents an error in the parameters of the meta element.
+	ErrMeta = "expecting meta parameters sequenceId to be in the range 1-2147483647, sysUpTime to be a number, and language to be a language tag"
+)
+
+// TimeQuality represents the timeQuality structured data element (RFC5424 section 7.1).
+type TimeQuality struct {
+	TZKnown      *bool
+	IsSynced     *bool
+	SyncAccuracy *uint64 // Microseconds
+}
+
+// Origin represents the origin structured data element (RFC5424 section 7.2).
+//
+// Notice that only one ip parameter is supported, since parameters are stored by name.
+type Origin struct {
+	IP           *string
+	EnterpriseID *string
+	Software     *string
+	SWVersion    *string
+}
+
+// Meta represents the meta structured data element (RFC5424 section 7.3).
+type Meta struct {
+	SequenceID *uint32
+	SysUpTime  *uint64 // Hundredths of a second
+	Language   *string
+}
+
+// GetTimeQuality returns the timeQuality element, or nil when the message does not contain it.
+//
+// It returns an error when any of its parameters is not valid.
+func (sm *SyslogMessage) GetTimeQuality() (*TimeQuality, error) {
+	params, ok := sm.element(TimeQualityID)
+	if !ok {
+		return nil, nil
+	}
+
+	// The parameters are checked in a fixed order, so that the same input always gives the same error
+	tq := &TimeQuality{}
+	if value, ok := params["tzKnown"]; ok {
+		b, ok := parseFlag(value)
+		if !ok {
+			return nil, fmt.Errorf(ErrTimeQuality+" [got %s=%q]", "tzKnown", value)
+		}
+		tq.TZKnown = &b
+	}
+	if value, ok := params["isSynced"]; ok {
+		b, ok := parseFlag(value)
+		if !ok {
+			return nil, fmt.Errorf(ErrTimeQuality+" [got %s=%q]", "isSynced", value)
+		}
+		tq.IsSynced = &b
+	}
+	if value, ok := params["syncAccuracy"]; ok {
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(ErrTimeQuality+" [got %s=%q]", "syncAccuracy", value)
+		}
+		tq.SyncAccuracy = &n
+	}
+	if err := tq.validate(); err != nil {
+		return nil, err
+	}
+
+	return tq, nil
+}
+
+// GetOrigin returns the origin element, or nil when the message does not contain it.
+//
+// It returns an error when any of its parameters is not valid.
+func (sm *SyslogMessage) GetOrigin() (*Origin, error) {
+	params, ok := sm.element(OriginID)
+	if !ok {
+		return nil, nil
+	}
+
+	o := &Origin{}
+	if value, ok := params["ip"]; ok {
+		o.IP = &value
+	}
+	if value, ok := params["enterpriseId"]; ok {
+		o.EnterpriseID = &value
+	}
+	if value, ok := params["software"]; ok {
+		o.Software = &value
+	}
+	if value, ok := params["swVersion"]; ok {
+		o.SWVersion = &value
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// GetMeta returns the meta element, or nil when the message does not contain it.
+//
+// It returns an error when any of its parameters is not valid.
+func (sm *SyslogMessage) GetMeta() (*Meta, error) {
+	params, ok := sm.element(MetaID)
+	if !ok {
+		return nil, nil
+	}
+
+	m := &Meta{}
+	if value, ok := params["sequenceId"]; ok {
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf(ErrMeta+" [got %s=%q]", "sequenceId", value)
+		}
+		id := uint32(n)
+		m.SequenceID = &id
+	}
+	if value, ok := params["sysUpTime"]; ok {
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(ErrMeta+" [got %s=%q]", "sysUpTime", value)
+		}
+		m.SysUpTime = &n
+	}
+	if value, ok := params["language"]; ok {
+		m.Language = &value
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SetTimeQuality sets the timeQuality element, replacing the existing one.
+//
+// It ignores invalid values.
+func (sm *SyslogMessage) SetTimeQuality(value TimeQuality) Builder {
+	if value.validate() != nil {
+		return sm
+	}
+
+	params := map[string]string{}
+	if value.TZKnown != nil {
+		params["tzKnown"] = formatFlag(*value.TZKnown)
+	}
+	if value.IsSynced != nil {
+		params["isSynced"] = formatFlag(*value.IsSynced)
+	}
+	if value.SyncAccuracy != nil {
+		params["syncAccuracy"] = strconv.FormatUint(*value.SyncAccuracy, 10)
+	}
+
+	return sm.setElement(TimeQualityID, params)
+}
+
+// SetOrigin sets the origin element, replacing the existing one.
+//
+// It ignores invalid values.
+func (sm *SyslogMessage) SetOrigin(value Origin) Builder {
+	if value.validate() != nil {
+		return sm
+	}
+
+	params := map[string]string{}
+	if value.IP != nil {
+		params["ip"] = *value.IP
+	}
+	if value.EnterpriseID != nil {
+		params["enterpriseId"] = *value.EnterpriseID
+	}
+	if value.Software != nil {
+		params["software"] = *value.Software
+	}
+	if value.SWVersion != nil {
+		params["swVersion"] = *value.SWVersion
+	}
+
+	return sm.setElement(OriginID, params)
+}
+
+// SetMeta sets the meta element, replacing the existing one.
+//
+// It ignores invalid values.
+func (sm *SyslogMessage) SetMeta(value Meta) Builder {
+	if value.validate() != nil {
+		return sm
+	}
+
+	params := map[string]string{}
+	if value.SequenceID != nil {
+		params["sequenceId"] = strconv.FormatUint(uint64(*value.SequenceID), 10)
+	}
+	if value.SysUpTime != nil {
+		params["sysUpTime"] = strconv.FormatUint(*value.SysUpTime, 10)
+	}
+	if value.Language != nil {
+		params["language"] = *value.Language
+	}
+
+	return sm.setElement(MetaID, params)
+}
+
+func (tq *TimeQuality) validate() error {
+	// RFC5424 section 7.1.3
+	if tq.SyncAccuracy != nil && tq.IsSynced != nil && !*tq.IsSynced {
+		return fmt.Errorf(ErrTimeQuality+" [got %s=%q]", "syncAccuracy", strconv.FormatUint(*tq.SyncAccuracy, 10))
+	}
+
+	return nil
+}
+
+func (o *Origin) validate() error {
+	if o.IP != nil && net.ParseIP(*o.IP) == nil {
+		return fmt.Errorf(ErrOrigin+" [got %s=%q]", "ip", *o.IP)
+	}
+	if o.EnterpriseID != nil && !isEnterpriseID(*o.EnterpriseID) {
+		return fmt.Errorf(ErrOrigin+" [got %s=%q]", "enterpriseId", *o.EnterpriseID)
+	}
+	if o.Software != nil && !inLength(*o.Software, 48) {
+		return fmt.Errorf(ErrOrigin+" [got %s=%q]", "software", *o.Software)
+	}
+	if o.SWVersion != nil && !inLength(*o.SWVersion, 32) {
+		return fmt.Errorf(ErrOrigin+" [got %s=%q]", "swVersion", *o.SWVersion)
+	}
+
+	return nil
+}
+
+func (m *Meta) validate() error {
+	if m.SequenceID != nil && (*m.SequenceID < 1 || *m.SequenceID > 2147483647) {
+		return fmt.Errorf(ErrMeta+" [got %s=%q]", "sequenceId", strconv.FormatUint(uint64(*m.SequenceID), 10))
+	}
+	if m.Language != nil && !isLanguageTag(*m.Language) {
+		return fmt.Errorf(ErrMeta+" [got %s=%q]", "language", *m.Language)
+	}
+
+	return nil
+}
+
+func (sm *SyslogMessage) element(id string) (map[string]string, bool) {
+	if sm.StructuredData == nil {
+		return nil, false
+	}
+	params, ok := (*sm.StructuredData)[id]
+
+	return params, ok
+}
+
+func (sm *SyslogMessage) setElement(id string, params map[string]string) *SyslogMessage {
+	if sm.StructuredData == nil {
+		sm.StructuredData = &map[string]map[string]string{}
+	}
+	(*sm.StructuredData)[id] = params
+
+	return sm
+}
+
+func parseFlag(value string) (bool, bool) {
+	switch value {
+	case "0":
+		return false, true
+	case "1":
+		return true, true
+	}
+
+	return false, false
+}
+
+func formatFlag(value bool) string {
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
+
+// inLength tells whether the input is made of 1 to limit characters.
+func inLength(s string, limit int) bool {
+	n := utf8.RuneCountInString(s)
+	return n > 0 && n <= limit
+}
+
+// validSdID tells whether id is either a registered one or in the name@<private enterprise number> format (RFC5424 section 6.3.2).
+func validSdID(registry SdIDRegistry, id string) bool {
+	at := strings.IndexByte(id, '@')
+	if at < 0 {
+		return registry.Registered(id)
+	}
+	name, pen := id[:at], id[at+1:]
+	if name == "" || pen == "" {
+		return false
+	}
+	for i := 0; i < len(pen); i++ {
+		if pen[i] < '0' || pen[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isEnterpriseID tells whether the input is a private enterprise number, optionally followed by other numbers (eg., 32473.1.2).
+func isEnterpriseID(s string) bool {
+	digits := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.' && digits > 0:
+			digits = 0
+		default:
+			return false
+		}
+	}
+
+	return digits > 0
+}
+
+// isLanguageTag tells whether the input looks like a BCP47 language tag (eg., en-US).
+//
+// It only checks that its subtags are made of 1 to 8 alphanumeric characters and that the first one is alphabetic.
+func isLanguageTag(s string) bool {
+	size := 0
+	first := true
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == '-' {
+			if size == 0 || size > 8 {
+				return false
+			}
+			size = 0
+			first = false
+			continue
+		}
+		c := s[i]
+		alpha := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !alpha && (first || c < '0' || c > '9') {
+			return false
+		}
+		size++
+	}
+
+	return true
+}
@@ -0,0 +1,168 @@
+package rfc5424
+
+import (
+	"testing"
+
+	syslogtesting "github.com/influxdata/go-syslog/v3/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func boolAddress(b bool) *bool {
+	return &b
+}
+
+func uint64Address(n uint64) *uint64 {
+	return &n
+}
+
+func uint32Address(n uint32) *uint32 {
+	return &n
+}
+
+func TestGetIANAElements(t *testing.T) {
+	input := `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [timeQuality tzKnown="1" isSynced="1" syncAccuracy="60000"][origin ip="192.0.2.1" enterpriseId="32473.1" software="test" swVersion="1.0"][meta sequenceId="29" sysUpTime="4215" language="en-US"] message`
+	m, err := NewMachine().Parse([]byte(input))
+	assert.Nil(t, err)
+	sm := m.(*SyslogMessage)
+
+	tq, err := sm.GetTimeQuality()
+	assert.Nil(t, err)
+	assert.Equal(t, &TimeQuality{
+		TZKnown:      boolAddress(true),
+		IsSynced:     boolAddress(true),
+		SyncAccuracy: uint64Address(60000),
+	}, tq)
+
+	o, err := sm.GetOrigin()
+	assert.Nil(t, err)
+	assert.Equal(t, &Origin{
+		IP:           syslogtesting.StringAddress("192.0.2.1"),
+		EnterpriseID: syslogtesting.StringAddress("32473.1"),
+		Software:     syslogtesting.StringAddress("test"),
+		SWVersion:    syslogtesting.StringAddress("1.0"),
+	}, o)
+
+	meta, err := sm.GetMeta()
+	assert.Nil(t, err)
+	assert.Equal(t, &Meta{
+		SequenceID: uint32Address(29),
+		SysUpTime:  uint64Address(4215),
+		Language:   syslogtesting.StringAddress("en-US"),
+	}, meta)
+}
+
+func TestGetMissingIANAElements(t *testing.T) {
+	sm := (&SyslogMessage{}).SetPriority(1).SetVersion(1).SetParameter("ex@32473", "iut", "3").(*SyslogMessage)
+
+	tq, err := sm.GetTimeQuality()
+	assert.Nil(t, tq)
+	assert.Nil(t, err)
+	o, err := sm.GetOrigin()
+	assert.Nil(t, o)
+	assert.Nil(t, err)
+	meta, err := sm.GetMeta()
+	assert.Nil(t, meta)
+	assert.Nil(t, err)
+
+	// Elements without parameters
+	sm.SetElementID(TimeQualityID)
+	tq, err = sm.GetTimeQuality()
+	assert.Equal(t, &TimeQuality{}, tq)
+	assert.Nil(t, err)
+}
+
+func TestGetInvalidIANAElements(t *testing.T) {
+	cases := []struct {
+		input string
+		err   string
+	}{
+		{`[timeQuality tzKnown="yes"]`, ErrTimeQuality + ` [got tzKnown="yes"]`},
+		{`[timeQuality isSynced="2"]`, ErrTimeQuality + ` [got isSynced="2"]`},
+		{`[timeQuality syncAccuracy="-1"]`, ErrTimeQuality + ` [got syncAccuracy="-1"]`},
+		{`[timeQuality isSynced="0" syncAccuracy="10"]`, ErrTimeQuality + ` [got syncAccuracy="10"]`},
+		{`[origin ip="192.0.2"]`, ErrOrigin + ` [got ip="192.0.2"]`},
+		{`[origin enterpriseId="32473."]`, ErrOrigin + ` [got enterpriseId="32473."]`},
+		{`[origin software=""]`, ErrOrigin + ` [got software=""]`},
+		{`[origin swVersion="123456789012345678901234567890123"]`, ErrOrigin + ` [got swVersion="123456789012345678901234567890123"]`},
+		{`[meta sequenceId="0"]`, ErrMeta + ` [got sequenceId="0"]`},
+		{`[meta sequenceId="2147483648"]`, ErrMeta + ` [got sequenceId="2147483648"]`},
+		{`[meta sysUpTime="1.5"]`, ErrMeta + ` [got sysUpTime="1.5"]`},
+		{`[meta language="en_US"]`, ErrMeta + ` [got language="en_US"]`},
+		{`[meta language="1en"]`, ErrMeta + ` [got language="1en"]`},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := NewMachine().Parse([]byte("<1>1 - - - - - " + tc.input))
+			assert.Nil(t, err)
+			sm := m.(*SyslogMessage)
+
+			var gerr error
+			for id := range *sm.StructuredData {
+				switch id {
+				case TimeQualityID:
+					_, gerr = sm.GetTimeQuality()
+				case OriginID:
+					_, gerr = sm.GetOrigin()
+				case MetaID:
+					_, gerr = sm.GetMeta()
+				}
+			}
+			assert.EqualError(t, gerr, tc.err)
+		})
+	}
+}
+
+func TestGetInvalidIANAElementsOrder(t *testing.T) {
+	m, err := NewMachine().Parse([]byte(`<1>1 - - - - - [timeQuality tzKnown="yes" isSynced="2" syncAccuracy="-1"][origin ip="192.0.2" enterpriseId="32473." software=""][meta sequenceId="x" sysUpTime="1.5" language="1en"]`))
+	assert.Nil(t, err)
+	sm := m.(*SyslogMessage)
+
+	// The same input always gives the same error, whatever the order of the parameters in their map
+	for i := 0; i < 20; i++ {
+		_, err := sm.GetTimeQuality()
+		assert.EqualError(t, err, ErrTimeQuality+` [got tzKnown="yes"]`)
+		_, err = sm.GetOrigin()
+		assert.EqualError(t, err, ErrOrigin+` [got ip="192.0.2"]`)
+		_, err = sm.GetMeta()
+		assert.EqualError(t, err, ErrMeta+` [got sequenceId="x"]`)
+	}
+}
+
+func TestSetIANAElements(t *testing.T) {
+	m := &SyslogMessage{}
+	m.SetPriority(1).
+		SetVersion(1).
+		SetTimeQuality(TimeQuality{TZKnown: boolAddress(true), IsSynced: boolAddress(false)}).
+		SetOrigin(Origin{IP: syslogtesting.StringAddress("::1"), Software: syslogtesting.StringAddress(`"go-syslog"`)}).
+		SetMeta(Meta{SequenceID: uint32Address(1), Language: syslogtesting.StringAddress("it")})
+
+	str, err := m.String()
+	assert.Nil(t, err)
+	assert.Equal(t, `<1>1 - - - - - [meta language="it" sequenceId="1"][origin ip="::1" software="\"go-syslog\""][timeQuality isSynced="0" tzKnown="1"]`, str)
+
+	// The elements are replaced
+	m.SetMeta(Meta{SysUpTime: uint64Address(10)})
+	meta, err := m.GetMeta()
+	assert.Nil(t, err)
+	assert.Equal(t, &Meta{SysUpTime: uint64Address(10)}, meta)
+
+	// Invalid values are ignored
+	m.SetTimeQuality(TimeQuality{IsSynced: boolAddress(false), SyncAccuracy: uint64Address(1)})
+	m.SetOrigin(Origin{IP: syslogtesting.StringAddress("localhost")})
+	m.SetMeta(Meta{SequenceID: uint32Address(0)})
+
+	str, err = m.String()
+	assert.Nil(t, err)
+	assert.Equal(t, `<1>1 - - - - - [meta sysUpTime="10"][origin ip="::1" software="\"go-syslog\""][timeQuality isSynced="0" tzKnown="1"]`, str)
+
+	// Round-trip
+	p, err := NewParser().Parse([]byte(str))
+	assert.Nil(t, err)
+	o, err := p.(*SyslogMessage).GetOrigin()
+	assert.Nil(t, err)
+	assert.Equal(t, `"go-syslog"`, *o.Software)
+}
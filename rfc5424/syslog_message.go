@@ -7,6 +7,11 @@ import (
 	"github.com/influxdata/go-syslog/v3/common"
 )
 
+// Format is the name of the format of RFC5424 syslog messages.
+//
+// It is also the value of the "format" key of their JSON representation.
+const Format = "rfc5424"
+
 type syslogMessage struct {
 	prioritySet    bool // We explictly flag the setting of priority since its zero value is a valid priority by RFC 5424
 	timestampSet   bool // We explictly flag the setting of timestamp since its zero value is a valid timestamp by RFC 5424
@@ -20,6 +25,7 @@ type syslogMessage struct {
 	msgID          string
 	structuredData map[string]map[string]string
 	message        string
+	raw            *Raw
 }
 
 func (sm *syslogMessage) minimal() bool {
@@ -54,6 +60,10 @@ func (sm *syslogMessage) export() *SyslogMessage {
 	if sm.message != "" {
 		out.Message = &sm.message
 	}
+	if sm.raw != nil {
+		sm.raw.close()
+		out.Raw = sm.raw
+	}
 
 	return out
 }
@@ -72,6 +82,10 @@ type Builder interface {
 	SetElementID(value string) Builder
 	SetParameter(id string, name string, value string) Builder
 	SetMessage(value string) Builder
+
+	SetTimeQuality(value TimeQuality) Builder
+	SetOrigin(value Origin) Builder
+	SetMeta(value Meta) Builder
 }
 
 // SyslogMessage represents a RFC5424 syslog message.
@@ -80,6 +94,7 @@ type SyslogMessage struct {
 
 	Version        uint16 // Grammar mandates that version cannot be 0, so we can use the 0 value of uint16 to signal nil
 	StructuredData *map[string]map[string]string
+	Raw            *Raw // Only present when parsing with the WithRaw option
 }
 
 // Valid tells whether the receiving RFC5424 SyslogMessage is well-formed or not.
@@ -89,3 +104,13 @@ func (sm *SyslogMessage) Valid() bool {
 	// A nil priority or a 0 version means that the message is not valid
 	return sm.Base.Valid() && common.ValidVersion(sm.Version)
 }
+
+// Format returns "rfc5424".
+func (sm *SyslogMessage) Format() string {
+	return Format
+}
+
+// GetStructuredData returns the structured data.
+func (sm *SyslogMessage) GetStructuredData() *map[string]map[string]string {
+	return sm.StructuredData
+}
@@ -0,0 +1,26 @@
+package rfc5424
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessor(t *testing.T) {
+	m, err := NewMachine().Parse([]byte(`<165>4 2018-10-11T22:14:15.003Z mymach.it e - 1 [ex@32473 iut="3"] An application event log entry...`))
+	assert.Nil(t, err)
+
+	a, ok := m.(syslog.Accessor)
+	assert.True(t, ok)
+	assert.Equal(t, "rfc5424", a.Format())
+	assert.Equal(t, uint8(165), *a.GetPriority())
+	assert.Equal(t, time.Date(2018, 10, 11, 22, 14, 15, 3000000, time.UTC), *a.GetTimestamp())
+	assert.Equal(t, "mymach.it", *a.GetHostname())
+	assert.Equal(t, "e", *a.GetAppname())
+	assert.Nil(t, a.GetProcID())
+	assert.Equal(t, "1", *a.GetMsgID())
+	assert.Equal(t, "An application event log entry...", *a.GetMessage())
+	assert.Equal(t, map[string]map[string]string{"ex@32473": {"iut": "3"}}, *a.GetStructuredData())
+}
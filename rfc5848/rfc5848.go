@@ -0,0 +1,374 @@
+// Package rfc5848 implements the verification and the signing of syslog messages as per RFC5848 (syslog-sign).
+//
+// The signer of a stream of RFC5424 syslog messages periodically emits signature blocks,
+// which are messages with a "ssign" structured data element containing the hashes of the previous messages and a signature of the block itself.
+// The public key to verify them can either be supplied locally or be sent in certificate blocks,
+// which are messages with a "ssign-cert" structured data element containing fragments of a payload block that carries the key.
+//
+// Only the OpenPGP DSA signature scheme is supported, with SHA1 or SHA256 hashes.
+// The signatures are encoded as the DSA values r and s as OpenPGP multiprecision integers (RFC4880 section 3.2).
+// Public keys are sent either as PKIX certificates (key blob type C) or as PKIX public keys (key blob type K), DER encoded.
+//
+// Ref.: https://tools.ietf.org/html/rfc5848
+package rfc5848
+
+import (
+	"crypto"
+	"crypto/dsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+)
+
+// IDs of the structured data elements defined by RFC5848.
+const (
+	SignatureBlockID   = "ssign"
+	CertificateBlockID = "ssign-cert"
+)
+
+// HashAlgorithm represents the hash algorithm of the VER parameter (RFC5848 section 4.2.1).
+type HashAlgorithm byte
+
+// Supported hash algorithms.
+const (
+	SHA1   HashAlgorithm = '1'
+	SHA256 HashAlgorithm = '2'
+)
+
+// Supported key blob types of the payload blocks (RFC5848 section 5.2.2).
+const (
+	KeyBlobPKIX = 'C'
+	KeyBlobKey  = 'K'
+)
+
+const (
+	protocolVersion = "01"
+	openPGPDSA      = '1'
+	maxLength       = 2048
+)
+
+var (
+	// ErrNoRaw is returned for messages parsed without the rfc5424.WithRaw option, since their original octets are needed.
+	ErrNoRaw = errors.New("expecting a message parsed with the raw option")
+	// ErrVersion is returned for blocks with an unsupported VER parameter.
+	ErrVersion = errors.New("expecting protocol version 01, hash algorithm 1 (SHA1) or 2 (SHA256), and signature scheme 1 (OpenPGP DSA)")
+	// ErrNoKey is returned by signers without a key.
+	ErrNoKey = errors.New("expecting a DSA private key")
+	// ErrKeyBlob is returned for payload blocks that do not contain a supported key.
+	ErrKeyBlob = errors.New("expecting a payload block containing a PKIX certificate or a PKIX DSA public key")
+)
+
+func (h HashAlgorithm) hash() crypto.Hash {
+	if h == SHA1 {
+		return crypto.SHA1
+	}
+	return crypto.SHA256
+}
+
+func (h HashAlgorithm) sum(data []byte) []byte {
+	if h == SHA1 {
+		s := sha1.Sum(data)
+		return s[:]
+	}
+	s := sha256.Sum256(data)
+	return s[:]
+}
+
+// block contains the parameters in common among signature and certificate blocks.
+type block struct {
+	message  *rfc5424.SyslogMessage
+	hostname string
+	hash     HashAlgorithm
+	rsid     uint64
+	sg       int
+	spri     int
+	sign     []byte
+}
+
+// signatureBlock represents the "ssign" element (RFC5848 section 4.2).
+type signatureBlock struct {
+	block
+	gbc    uint64
+	fmn    uint64
+	hashes [][]byte
+}
+
+// certificateBlock represents the "ssign-cert" element (RFC5848 section 5.3).
+type certificateBlock struct {
+	block
+	tpbl  int
+	index int
+	frag  string
+}
+
+// params reads the parameters of the given element, failing when any of the given names is missing.
+func params(m *rfc5424.SyslogMessage, id string, names ...string) (map[string]string, error) {
+	p := (*m.StructuredData)[id]
+	for _, name := range names {
+		if _, ok := p[name]; !ok {
+			return nil, fmt.Errorf("expecting the %s parameter in the %s element", name, id)
+		}
+	}
+
+	return p, nil
+}
+
+func parseNumber(p map[string]string, name string, digits int, max uint64) (uint64, error) {
+	v := p[name]
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil || len(v) > digits || n > max {
+		return 0, fmt.Errorf("expecting the %s parameter to be a number up to %d [got %q]", name, max, v)
+	}
+
+	return n, nil
+}
+
+func parseBlock(m *rfc5424.SyslogMessage, p map[string]string) (block, error) {
+	b := block{message: m}
+	if m.Hostname != nil {
+		b.hostname = *m.Hostname
+	}
+
+	ver := p["VER"]
+	if len(ver) != 4 || ver[:2] != protocolVersion || (ver[2] != byte(SHA1) && ver[2] != byte(SHA256)) || ver[3] != openPGPDSA {
+		return b, ErrVersion
+	}
+	b.hash = HashAlgorithm(ver[2])
+
+	var err error
+	if b.rsid, err = parseNumber(p, "RSID", 10, 9999999999); err != nil {
+		return b, err
+	}
+	sg, err := parseNumber(p, "SG", 1, 3)
+	if err != nil {
+		return b, err
+	}
+	b.sg = int(sg)
+	spri, err := parseNumber(p, "SPRI", 3, 191)
+	if err != nil {
+		return b, err
+	}
+	b.spri = int(spri)
+	if b.sign, err = base64.StdEncoding.DecodeString(p["SIGN"]); err != nil {
+		return b, fmt.Errorf("expecting the SIGN parameter to be base64 encoded [got %q]", p["SIGN"])
+	}
+
+	return b, nil
+}
+
+func parseSignatureBlock(m *rfc5424.SyslogMessage) (*signatureBlock, error) {
+	p, err := params(m, SignatureBlockID, "VER", "RSID", "SG", "SPRI", "GBC", "FMN", "CNT", "HB", "SIGN")
+	if err != nil {
+		return nil, err
+	}
+	b, err := parseBlock(m, p)
+	if err != nil {
+		return nil, err
+	}
+
+	sb := &signatureBlock{block: b}
+	if sb.gbc, err = parseNumber(p, "GBC", 10, 9999999999); err != nil {
+		return nil, err
+	}
+	if sb.fmn, err = parseNumber(p, "FMN", 10, 9999999999); err != nil {
+		return nil, err
+	}
+	cnt, err := parseNumber(p, "CNT", 2, 99)
+	if err != nil {
+		return nil, err
+	}
+	hashes := strings.Fields(p["HB"])
+	if uint64(len(hashes)) != cnt {
+		return nil, fmt.Errorf("expecting the HB parameter to contain CNT hashes [got %d, CNT %d]", len(hashes), cnt)
+	}
+	for _, h := range hashes {
+		d, err := base64.StdEncoding.DecodeString(h)
+		if err != nil || len(d) != sb.hash.hash().Size() {
+			return nil, fmt.Errorf("expecting the HB parameter to contain base64 encoded hashes [got %q]", h)
+		}
+		sb.hashes = append(sb.hashes, d)
+	}
+
+	return sb, nil
+}
+
+func parseCertificateBlock(m *rfc5424.SyslogMessage) (*certificateBlock, error) {
+	p, err := params(m, CertificateBlockID, "VER", "RSID", "SG", "SPRI", "TPBL", "INDEX", "FLEN", "FRAG", "SIGN")
+	if err != nil {
+		return nil, err
+	}
+	b, err := parseBlock(m, p)
+	if err != nil {
+		return nil, err
+	}
+
+	cb := &certificateBlock{block: b, frag: p["FRAG"]}
+	tpbl, err := parseNumber(p, "TPBL", 10, 9999999999)
+	if err != nil {
+		return nil, err
+	}
+	index, err := parseNumber(p, "INDEX", 10, tpbl)
+	if err != nil || index == 0 {
+		return nil, fmt.Errorf("expecting the INDEX parameter to be a number in the range 1-TPBL [got %q]", p["INDEX"])
+	}
+	flen, err := parseNumber(p, "FLEN", 4, tpbl-index+1)
+	if err != nil || flen != uint64(len(cb.frag)) {
+		return nil, fmt.Errorf("expecting the FLEN parameter to be the length of the FRAG parameter [got %q]", p["FLEN"])
+	}
+	cb.tpbl = int(tpbl)
+	cb.index = int(index)
+
+	return cb, nil
+}
+
+// signedOctets returns the original octets of the message with an empty value for the SIGN parameter of the given element.
+func signedOctets(m *rfc5424.SyslogMessage, id string) []byte {
+	for _, e := range m.Raw.Elements {
+		if e.ID != id {
+			continue
+		}
+		for _, p := range e.Params {
+			if p.Name == "SIGN" {
+				out := make([]byte, 0, len(m.Raw.Input)-(p.Value.End-p.Value.Start))
+				out = append(out, m.Raw.Input[:p.Value.Start]...)
+				return append(out, m.Raw.Input[p.Value.End:]...)
+			}
+		}
+	}
+
+	return m.Raw.Input
+}
+
+// digest returns the hash of data truncated to the size of the DSA subgroup order (RFC4880 section 5.2.2).
+func digest(h HashAlgorithm, q *big.Int, data []byte) []byte {
+	d := h.sum(data)
+	if size := (q.BitLen() + 7) / 8; len(d) > size {
+		d = d[:size]
+	}
+
+	return d
+}
+
+func sign(key *dsa.PrivateKey, h HashAlgorithm, data []byte, rand interface{ Read([]byte) (int, error) }) ([]byte, error) {
+	r, s, err := dsa.Sign(rand, key, digest(h, key.Q, data))
+	if err != nil {
+		return nil, err
+	}
+
+	return appendMPI(appendMPI(nil, r), s), nil
+}
+
+func verify(key *dsa.PublicKey, h HashAlgorithm, data, signature []byte) bool {
+	r, rest, ok := readMPI(signature)
+	if !ok {
+		return false
+	}
+	s, rest, ok := readMPI(rest)
+	if !ok || len(rest) > 0 {
+		return false
+	}
+
+	return dsa.Verify(key, digest(h, key.Q, data), r, s)
+}
+
+// appendMPI appends n as an OpenPGP multiprecision integer: its length in bits on two octets followed by its octets.
+func appendMPI(dst []byte, n *big.Int) []byte {
+	bits := n.BitLen()
+	dst = append(dst, byte(bits>>8), byte(bits))
+	return append(dst, n.Bytes()...)
+}
+
+func readMPI(data []byte) (*big.Int, []byte, bool) {
+	if len(data) < 2 {
+		return nil, nil, false
+	}
+	size := (int(data[0])<<8 | int(data[1]) + 7) / 8
+	if len(data) < 2+size {
+		return nil, nil, false
+	}
+
+	return new(big.Int).SetBytes(data[2 : 2+size]), data[2+size:], true
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue
+}
+
+type subjectPublicKeyInfo struct {
+	Algorithm algorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+var oidPublicKeyDSA = asn1.ObjectIdentifier{1, 2, 840, 10040, 4, 1}
+
+// marshalPublicKey encodes the DSA public key as a DER PKIX public key, since the standard library cannot.
+func marshalPublicKey(key *dsa.PublicKey) ([]byte, error) {
+	params, err := asn1.Marshal(struct{ P, Q, G *big.Int }{key.P, key.Q, key.G})
+	if err != nil {
+		return nil, err
+	}
+	y, err := asn1.Marshal(key.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: algorithmIdentifier{Algorithm: oidPublicKeyDSA, Parameters: asn1.RawValue{FullBytes: params}},
+		PublicKey: asn1.BitString{Bytes: y, BitLength: 8 * len(y)},
+	})
+}
+
+// parsePayload extracts the public key from a payload block (RFC5848 section 5.2),
+// verifying the certificates against roots, when any.
+func parsePayload(payload string, roots *x509.CertPool) (*dsa.PublicKey, error) {
+	// Timestamp, key blob type, and base64 encoded key blob, separated by spaces
+	parts := strings.SplitN(payload, " ", 3)
+	if len(parts) != 3 || len(parts[1]) != 1 {
+		return nil, ErrKeyBlob
+	}
+	blob, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrKeyBlob
+	}
+
+	var pub interface{}
+	switch parts[1][0] {
+	case KeyBlobPKIX:
+		cert, err := x509.ParseCertificate(blob)
+		if err != nil {
+			return nil, err
+		}
+		if roots != nil {
+			if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}); err != nil {
+				return nil, err
+			}
+		}
+		pub = cert.PublicKey
+	case KeyBlobKey:
+		if roots != nil {
+			return nil, errors.New("expecting a certificate since roots are given")
+		}
+		if pub, err = x509.ParsePKIXPublicKey(blob); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrKeyBlob
+	}
+
+	key, ok := pub.(*dsa.PublicKey)
+	if !ok {
+		return nil, ErrKeyBlob
+	}
+
+	return key, nil
+}
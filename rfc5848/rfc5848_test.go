@@ -0,0 +1,277 @@
+package rfc5848
+
+import (
+	"bytes"
+	"crypto/dsa"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testKey     dsa.PrivateKey
+	testKeyOnce sync.Once
+)
+
+func key(t testing.TB) *dsa.PrivateKey {
+	testKeyOnce.Do(func() {
+		if err := dsa.GenerateParameters(&testKey.Parameters, rand.Reader, dsa.L1024N160); err != nil {
+			t.Fatal(err)
+		}
+		if err := dsa.GenerateKey(&testKey, rand.Reader); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	return &testKey
+}
+
+// stream returns n messages, each one followed by the signature block when the signer emits it.
+func stream(t *testing.T, s *Signer, n int) ([][]byte, [][]byte) {
+	var messages, blocks [][]byte
+	for i := 1; i <= n; i++ {
+		m := []byte(fmt.Sprintf("<13>1 2003-10-11T22:14:15.003Z host app - - - message %d", i))
+		messages = append(messages, m)
+		b, err := s.Add(m)
+		assert.Nil(t, err)
+		if b != nil {
+			blocks = append(blocks, b)
+		}
+	}
+	b, err := s.Flush()
+	assert.Nil(t, err)
+	if b != nil {
+		blocks = append(blocks, b)
+	}
+
+	return messages, blocks
+}
+
+func parse(t *testing.T, input []byte) *rfc5424.SyslogMessage {
+	t.Helper()
+	m, err := rfc5424.NewParser(rfc5424.WithRaw()).Parse(input)
+	assert.Nil(t, err)
+	return m.(*rfc5424.SyslogMessage)
+}
+
+func verifier(t *testing.T, inputs [][]byte, options ...VerifierOption) *Report {
+	t.Helper()
+	v := NewVerifier(options...)
+	for _, input := range inputs {
+		assert.Nil(t, v.Add(parse(t, input)))
+	}
+	return v.Report()
+}
+
+func statuses(r *Report) []Status {
+	out := make([]Status, 0, len(r.Messages))
+	for _, res := range r.Messages {
+		out = append(out, res.Status)
+	}
+	return out
+}
+
+func TestSignAndVerifyWithLocalKey(t *testing.T) {
+	s := NewSigner(key(t), WithOrigin("host", "syslog-sign", ""))
+	messages, blocks := stream(t, s, 3)
+	assert.Len(t, blocks, 1)
+	assert.True(t, strings.HasPrefix(string(blocks[0]), `<110>1 `))
+	assert.Contains(t, string(blocks[0]), ` host syslog-sign - - [ssign VER="0121" RSID="0" SG="0" SPRI="0" GBC="0" FMN="1" CNT="3" HB="`)
+
+	r := verifier(t, append(messages, blocks...), WithKey("host", &key(t).PublicKey))
+	assert.Equal(t, []Status{Verified, Verified, Verified, Verified}, statuses(r))
+	assert.Empty(t, r.Missing)
+
+	// Any hostname
+	r = verifier(t, append(messages, blocks...), WithKey("", &key(t).PublicKey))
+	assert.Equal(t, []Status{Verified, Verified, Verified, Verified}, statuses(r))
+
+	// Without keys
+	r = verifier(t, append(messages, blocks...))
+	assert.Equal(t, []Status{Unsigned, Unsigned, Unsigned, Unsigned}, statuses(r))
+}
+
+func TestSignAndVerifyWithCertificateBlocks(t *testing.T) {
+	s := NewSigner(key(t), WithOrigin("host", "app", "1"), WithHashAlgorithm(SHA1), WithRebootSessionID(7))
+	certs, err := s.CertificateBlocks()
+	assert.Nil(t, err)
+	assert.Len(t, certs, 1)
+	messages, blocks := stream(t, s, 2)
+
+	inputs := append(append(certs, messages...), blocks...)
+	r := verifier(t, inputs, WithCertificateBlocks(nil))
+	assert.Equal(t, []Status{Verified, Verified, Verified, Verified}, statuses(r))
+
+	// The certificate blocks are not used by default
+	r = verifier(t, inputs)
+	assert.Equal(t, []Status{Unsigned, Unsigned, Unsigned, Unsigned}, statuses(r))
+
+	// Roots require the key to be sent as a certificate
+	r = verifier(t, inputs, WithCertificateBlocks(x509.NewCertPool()))
+	assert.Equal(t, []Status{Tampered, Unsigned, Unsigned, Unsigned}, statuses(r))
+
+	// The key sent in certificate blocks is bound to the reboot session
+	other := NewSigner(key(t), WithOrigin("host", "app", "1"), WithRebootSessionID(8))
+	messages, blocks = stream(t, other, 1)
+	r = verifier(t, append(append(certs, messages...), blocks...), WithCertificateBlocks(nil))
+	assert.Equal(t, []Status{Verified, Unsigned, Unsigned}, statuses(r))
+}
+
+func TestBlocksPassSdIDValidation(t *testing.T) {
+	s := NewSigner(key(t), WithOrigin("host", "app", "1"))
+	certs, err := s.CertificateBlocks()
+	assert.Nil(t, err)
+	_, blocks := stream(t, s, 2)
+
+	p := rfc5424.NewParser(rfc5424.WithSdIDValidation(nil))
+	for _, b := range append(certs, blocks...) {
+		_, err := p.Parse(b)
+		assert.Nil(t, err, string(b))
+	}
+}
+
+func TestVerifyMissingAndTamperedMessages(t *testing.T) {
+	s := NewSigner(key(t), WithOrigin("host", "app", ""))
+	messages, blocks := stream(t, s, 4)
+	pub := WithKey("host", &key(t).PublicKey)
+
+	// The third message is lost
+	r := verifier(t, append(append([][]byte{}, messages[0], messages[1], messages[3]), blocks...), pub)
+	assert.Equal(t, []Status{Verified, Verified, Verified, Verified}, statuses(r))
+	assert.Len(t, r.Missing, 1)
+	assert.Equal(t, "host", r.Missing[0].Hostname)
+	assert.Equal(t, uint64(3), r.Missing[0].Number)
+
+	// The second message is modified
+	modified := bytes.Replace(messages[1], []byte("message"), []byte("massage"), 1)
+	r = verifier(t, append(append([][]byte{}, messages[0], modified, messages[2], messages[3]), blocks...), pub)
+	assert.Equal(t, []Status{Verified, Tampered, Verified, Verified, Verified}, statuses(r))
+	assert.Len(t, r.Missing, 1)
+	assert.Equal(t, uint64(2), r.Missing[0].Number)
+
+	// The signature block is modified
+	tampered := bytes.Replace(blocks[0], []byte(`FMN="1"`), []byte(`FMN="2"`), 1)
+	r = verifier(t, append(append([][]byte{}, messages...), tampered), pub)
+	assert.Equal(t, []Status{Unsigned, Unsigned, Unsigned, Unsigned, Tampered}, statuses(r))
+	assert.Empty(t, r.Missing)
+
+	// Messages following the last signature block are not signed yet
+	later := []byte("<13>1 2003-10-11T22:14:15.003Z host app - - - message 5")
+	r = verifier(t, append(append([][]byte{}, messages...), append(blocks, later)...), pub)
+	assert.Equal(t, []Status{Verified, Verified, Verified, Verified, Verified, Unsigned}, statuses(r))
+
+	// Redundant signature blocks
+	r = verifier(t, append(append([][]byte{}, messages...), blocks[0], blocks[0]), pub)
+	assert.Equal(t, []Status{Verified, Verified, Verified, Verified, Verified, Verified}, statuses(r))
+	assert.Empty(t, r.Missing)
+}
+
+func TestVerifyUnverifiableMessages(t *testing.T) {
+	s := NewSigner(key(t), WithOrigin("host", "app", ""))
+	var messages, blocks [][]byte
+	for i := 1; i <= 6; i++ {
+		m := []byte(fmt.Sprintf("<13>1 2003-10-11T22:14:15.003Z host app - - - message %d", i))
+		messages = append(messages, m)
+		_, err := s.Add(m)
+		assert.Nil(t, err)
+		if i%2 == 0 {
+			b, err := s.Flush()
+			assert.Nil(t, err)
+			blocks = append(blocks, b)
+		}
+	}
+	pub := WithKey("host", &key(t).PublicKey)
+
+	// The second signature block is lost
+	r := verifier(t, [][]byte{messages[0], messages[1], blocks[0], messages[2], messages[3], messages[4], messages[5], blocks[2]}, pub)
+	assert.Equal(t, []Status{Verified, Verified, Verified, Unsigned, Unsigned, Verified, Verified, Verified}, statuses(r))
+
+	// Messages sent before signing began
+	before := []byte("<13>1 2003-10-11T22:14:15.003Z host app - - - message 0")
+	r = verifier(t, [][]byte{before, messages[0], messages[1], blocks[0]}, pub)
+	assert.Equal(t, []Status{Unsigned, Verified, Verified, Verified}, statuses(r))
+
+	// Messages injected among the covered ones
+	r = verifier(t, [][]byte{messages[0], before, messages[1], blocks[0]}, pub)
+	assert.Equal(t, []Status{Verified, Tampered, Verified, Verified}, statuses(r))
+}
+
+func TestSignerBlockLimits(t *testing.T) {
+	for _, h := range []HashAlgorithm{SHA1, SHA256} {
+		s := NewSigner(key(t), WithHashAlgorithm(h), WithOrigin("host", strings.Repeat("a", 48), strings.Repeat("p", 128)))
+		messages, blocks := stream(t, s, 250)
+		if h == SHA256 {
+			// Limited by the length of the blocks rather than by the number of hashes
+			assert.True(t, len(blocks) > 3)
+		}
+
+		inputs := [][]byte{}
+		fmn := 1
+		for _, b := range blocks {
+			assert.True(t, len(b) <= 2048, "block longer than 2048 octets: %d", len(b))
+			sb, err := parseSignatureBlock(parse(t, b))
+			assert.Nil(t, err)
+			assert.True(t, len(sb.hashes) <= 99)
+			assert.Equal(t, uint64(fmn), sb.fmn)
+			fmn += len(sb.hashes)
+			inputs = append(inputs, messages[sb.fmn-1:int(sb.fmn)-1+len(sb.hashes)]...)
+			inputs = append(inputs, b)
+		}
+		assert.Equal(t, 251, fmn)
+
+		r := verifier(t, inputs, WithKey("", &key(t).PublicKey))
+		for i, res := range r.Messages {
+			assert.Equal(t, Verified, res.Status, "message %d", i)
+		}
+	}
+}
+
+func TestVerifierErrors(t *testing.T) {
+	v := NewVerifier()
+	assert.Equal(t, ErrNoRaw, v.Add(nil))
+	m, _ := rfc5424.NewParser().Parse([]byte("<1>1 - - - - - -"))
+	assert.Equal(t, ErrNoRaw, v.Add(m.(*rfc5424.SyslogMessage)))
+
+	cases := []struct {
+		input string
+		err   string
+	}{
+		{`<110>1 - - - - - [ssign VER="0121"]`, "expecting the RSID parameter in the ssign element"},
+		{`<110>1 - - - - - [ssign VER="0131" RSID="0" SG="0" SPRI="0" GBC="0" FMN="1" CNT="0" HB="" SIGN=""]`, ErrVersion.Error()},
+		{`<110>1 - - - - - [ssign VER="0121" RSID="0" SG="4" SPRI="0" GBC="0" FMN="1" CNT="0" HB="" SIGN=""]`, `expecting the SG parameter to be a number up to 3 [got "4"]`},
+		{`<110>1 - - - - - [ssign VER="0121" RSID="0" SG="0" SPRI="0" GBC="0" FMN="1" CNT="2" HB="AAAA" SIGN=""]`, "expecting the HB parameter to contain CNT hashes [got 1, CNT 2]"},
+		{`<110>1 - - - - - [ssign VER="0121" RSID="0" SG="0" SPRI="0" GBC="0" FMN="1" CNT="1" HB="AAAA" SIGN=""]`, `expecting the HB parameter to contain base64 encoded hashes [got "AAAA"]`},
+		{`<110>1 - - - - - [ssign-cert VER="0121" RSID="0" SG="0" SPRI="0" TPBL="10" INDEX="0" FLEN="1" FRAG="x" SIGN=""]`, `expecting the INDEX parameter to be a number in the range 1-TPBL [got "0"]`},
+		{`<110>1 - - - - - [ssign-cert VER="0121" RSID="0" SG="0" SPRI="0" TPBL="10" INDEX="1" FLEN="2" FRAG="x" SIGN=""]`, `expecting the FLEN parameter to be the length of the FRAG parameter [got "2"]`},
+	}
+	for _, tc := range cases {
+		err := v.Add(parse(t, []byte(tc.input)))
+		assert.EqualError(t, err, tc.err)
+	}
+
+	r := v.Report()
+	assert.Len(t, r.Messages, len(cases))
+	for _, res := range r.Messages {
+		assert.Equal(t, Tampered, res.Status)
+	}
+}
+
+func TestSignerWithoutKey(t *testing.T) {
+	s := NewSigner(nil)
+	_, err := s.Add([]byte("<1>1 - - - - - -"))
+	assert.Equal(t, ErrNoKey, err)
+	_, err = s.CertificateBlocks()
+	assert.Equal(t, ErrNoKey, err)
+}
+
+func TestStatusString(t *testing.T) {
+	assert.Equal(t, "unsigned", Unsigned.String())
+	assert.Equal(t, "verified", Verified.String())
+	assert.Equal(t, "tampered", Tampered.String())
+}
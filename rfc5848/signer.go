@@ -0,0 +1,253 @@
+package rfc5848
+
+import (
+	"crypto/dsa"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+)
+
+const (
+	maxHashes      = 99
+	maxBlockNumber = 9999999999
+	fragmentLength = 1024
+)
+
+// SignerOption represents an option for the Signer.
+type SignerOption func(*Signer)
+
+// WithHashAlgorithm sets the hash algorithm (SHA256 by default).
+func WithHashAlgorithm(h HashAlgorithm) SignerOption {
+	return func(s *Signer) {
+		if h == SHA1 || h == SHA256 {
+			s.hash = h
+		}
+	}
+}
+
+// WithRebootSessionID sets the reboot session ID (0 by default, meaning that it is not persisted across reboots).
+//
+// It must be increased at every restart of the signer.
+func WithRebootSessionID(rsid uint64) SignerOption {
+	return func(s *Signer) {
+		if rsid <= maxBlockNumber {
+			s.rsid = rsid
+		}
+	}
+}
+
+// WithOrigin sets the HOSTNAME, APP-NAME, and PROCID of the blocks, which should be the ones of the signed messages.
+//
+// Empty values are written as nil values.
+func WithOrigin(hostname, appname, procid string) SignerOption {
+	return func(s *Signer) {
+		s.hostname = hostname
+		s.appname = appname
+		s.procid = procid
+	}
+}
+
+// WithBlockPriority sets the priority of the blocks (110 by default, ie. facility 13 and severity 6).
+func WithBlockPriority(priority uint8) SignerOption {
+	return func(s *Signer) {
+		if priority <= 191 {
+			s.priority = priority
+		}
+	}
+}
+
+// Signer emits the signature blocks and the certificate blocks for a stream of outgoing RFC5424 syslog messages.
+//
+// It uses a single signature group (SG 0) for all the messages.
+// It is not safe for concurrent use.
+type Signer struct {
+	key      *dsa.PrivateKey
+	hash     HashAlgorithm
+	rsid     uint64
+	priority uint8
+	hostname string
+	appname  string
+	procid   string
+	rand     io.Reader
+	now      func() time.Time
+	gbc      uint64
+	fmn      uint64
+	hashes   [][]byte
+}
+
+// NewSigner creates a signer using the given DSA key.
+func NewSigner(key *dsa.PrivateKey, options ...SignerOption) *Signer {
+	s := &Signer{
+		key:      key,
+		hash:     SHA256,
+		priority: 110,
+		rand:     rand.Reader,
+		now:      time.Now,
+		fmn:      1,
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+
+	return s
+}
+
+// Add hashes the octets of an outgoing message, as they are sent (ie., without any transport framing).
+//
+// It returns a signature block to send after the message when the block is full, nil otherwise.
+func (s *Signer) Add(message []byte) ([]byte, error) {
+	if s.key == nil {
+		return nil, ErrNoKey
+	}
+	h := s.hash.sum(message)
+
+	// Signature blocks cannot be longer than 2048 octets
+	if len(s.hashes) > 0 && s.length(len(s.hashes)+1) > maxLength {
+		out, err := s.Flush()
+		s.hashes = append(s.hashes, h)
+		return out, err
+	}
+	s.hashes = append(s.hashes, h)
+	if len(s.hashes) == maxHashes {
+		return s.Flush()
+	}
+
+	return nil, nil
+}
+
+// Flush returns a signature block for the messages added after the last one, nil when there are none.
+func (s *Signer) Flush() ([]byte, error) {
+	if len(s.hashes) == 0 {
+		return nil, nil
+	}
+
+	hb := make([]byte, 0, len(s.hashes)*base64.StdEncoding.EncodedLen(len(s.hashes[0])+1))
+	for i, h := range s.hashes {
+		if i > 0 {
+			hb = append(hb, ' ')
+		}
+		hb = append(hb, base64.StdEncoding.EncodeToString(h)...)
+	}
+	out, err := s.block(SignatureBlockID,
+		"GBC", strconv.FormatUint(s.gbc, 10),
+		"FMN", strconv.FormatUint(s.fmn, 10),
+		"CNT", strconv.Itoa(len(s.hashes)),
+		"HB", string(hb),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Counters wrap around after 10 digits
+	s.gbc = (s.gbc + 1) % (maxBlockNumber + 1)
+	s.fmn = (s.fmn+uint64(len(s.hashes))-1)%maxBlockNumber + 1
+	s.hashes = s.hashes[:0]
+
+	return out, nil
+}
+
+// CertificateBlocks returns the certificate blocks carrying the public key as a PKIX public key (key blob type K).
+//
+// They should be sent before the signature blocks, and periodically resent.
+func (s *Signer) CertificateBlocks() ([][]byte, error) {
+	if s.key == nil {
+		return nil, ErrNoKey
+	}
+	der, err := marshalPublicKey(&s.key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	payload := s.now().UTC().Format(rfc5424.RFC3339MICRO) + " " + string(KeyBlobKey) + " " + base64.StdEncoding.EncodeToString(der)
+
+	var blocks [][]byte
+	for start := 0; start < len(payload); start += fragmentLength {
+		end := start + fragmentLength
+		if end > len(payload) {
+			end = len(payload)
+		}
+		out, err := s.block(CertificateBlockID,
+			"TPBL", strconv.Itoa(len(payload)),
+			"INDEX", strconv.Itoa(start+1),
+			"FLEN", strconv.Itoa(end-start),
+			"FRAG", payload[start:end],
+		)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, out)
+	}
+
+	return blocks, nil
+}
+
+// unsigned writes a block with the common parameters, followed by the given ones (name and value pairs), and an empty SIGN parameter.
+func (s *Signer) unsigned(id string, params ...string) []byte {
+	out := make([]byte, 0, maxLength)
+	out = append(out, '<')
+	out = strconv.AppendUint(out, uint64(s.priority), 10)
+	out = append(out, ">1 "...)
+	out = s.now().UTC().AppendFormat(out, rfc5424.RFC3339MICRO)
+	for _, v := range []string{s.hostname, s.appname, s.procid} {
+		out = append(out, ' ')
+		if v == "" {
+			v = "-"
+		}
+		out = append(out, v...)
+	}
+	out = append(out, " - ["...)
+	out = append(out, id...)
+
+	params = append([]string{
+		"VER", protocolVersion + string(s.hash) + string(openPGPDSA),
+		"RSID", strconv.FormatUint(s.rsid, 10),
+		"SG", "0",
+		"SPRI", "0",
+	}, params...)
+	for i := 0; i < len(params); i += 2 {
+		out = append(out, ' ')
+		out = append(out, params[i]...)
+		out = append(out, '=', '"')
+		out = append(out, params[i+1]...)
+		out = append(out, '"')
+	}
+
+	return append(out, ` SIGN=""]`...)
+}
+
+// block writes a signed block.
+func (s *Signer) block(id string, params ...string) ([]byte, error) {
+	if s.key == nil {
+		return nil, ErrNoKey
+	}
+	out := s.unsigned(id, params...)
+	signature, err := sign(s.key, s.hash, out, s.rand)
+	if err != nil {
+		return nil, err
+	}
+
+	// Put the signature between the double quotes of the SIGN parameter
+	tail := len(out) - len(`"]`)
+	res := make([]byte, 0, len(out)+base64.StdEncoding.EncodedLen(len(signature)))
+	res = append(res, out[:tail]...)
+	res = append(res, base64.StdEncoding.EncodeToString(signature)...)
+
+	return append(res, out[tail:]...), nil
+}
+
+// length returns the maximum length of a signature block containing the given number of hashes.
+func (s *Signer) length(hashes int) int {
+	size := base64.StdEncoding.EncodedLen(s.hash.hash().Size())
+	// The signature contains two values as long as the subgroup order, each one with its length on two octets
+	signature := base64.StdEncoding.EncodedLen(2 * (2 + (s.key.Q.BitLen()+7)/8))
+
+	return len(s.unsigned(SignatureBlockID,
+		"GBC", strconv.FormatUint(s.gbc, 10),
+		"FMN", strconv.FormatUint(s.fmn, 10),
+		"CNT", strconv.Itoa(hashes),
+		"HB", "",
+	)) + hashes*(size+1) - 1 + signature
+}
@@ -0,0 +1,473 @@
+package rfc5848
+
+import (
+	"crypto/dsa"
+	"crypto/x509"
+	"sort"
+
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+)
+
+// Status represents the outcome of the verification of a message.
+type Status int
+
+const (
+	// Unsigned messages are not covered by any verified signature block (yet), or cannot be verified
+	// (eg., their signature block has been lost, or they have been sent before signing began),
+	// or are blocks whose key is not available.
+	Unsigned Status = iota
+	// Verified messages are covered by a verified signature block, or are blocks with a valid signature.
+	Verified
+	// Tampered messages must be among the ones covered by the verified signature blocks, but do not match any of their hashes
+	// (thus they have been modified or forged), or are malformed blocks or blocks with an invalid signature.
+	Tampered
+)
+
+func (s Status) String() string {
+	switch s {
+	case Verified:
+		return "verified"
+	case Tampered:
+		return "tampered"
+	}
+	return "unsigned"
+}
+
+// Result represents the outcome of the verification of a message.
+type Result struct {
+	Message *rfc5424.SyslogMessage
+	Status  Status
+}
+
+// Missing represents a message whose hash is in a verified signature block but that has not been received.
+type Missing struct {
+	Hostname string
+	RSID     uint64
+	SG       int
+	SPRI     int
+	Number   uint64 // The message number within its signature group
+	Hash     []byte
+}
+
+// Report contains the outcome of the verification of the messages added to a Verifier, in the same order.
+type Report struct {
+	Messages []Result
+	Missing  []Missing
+}
+
+// VerifierOption represents an option for the Verifier.
+type VerifierOption func(*Verifier)
+
+// WithKey supplies the public key of the signer with the given hostname.
+//
+// An empty hostname makes it the key of any signer without a specific one.
+// Local keys take precedence over the keys sent in certificate blocks.
+func WithKey(hostname string, key *dsa.PublicKey) VerifierOption {
+	return func(v *Verifier) {
+		v.keys[hostname] = key
+	}
+}
+
+// WithCertificateBlocks enables the use of the keys sent in certificate blocks.
+//
+// When roots are given, the keys must be sent as PKIX certificates valid for them.
+// Otherwise any key is accepted, thus it is only as trustworthy as the transport of the messages.
+func WithCertificateBlocks(roots *x509.CertPool) VerifierOption {
+	return func(v *Verifier) {
+		v.certificates = true
+		v.roots = roots
+	}
+}
+
+type entry struct {
+	message *rfc5424.SyslogMessage
+	sb      *signatureBlock
+	cb      *certificateBlock
+	invalid bool
+}
+
+// Verifier verifies a stream of RFC5424 syslog messages containing signature blocks and, optionally, certificate blocks.
+type Verifier struct {
+	keys         map[string]*dsa.PublicKey
+	certificates bool
+	roots        *x509.CertPool
+	entries      []entry
+}
+
+// NewVerifier creates a verifier.
+func NewVerifier(options ...VerifierOption) *Verifier {
+	v := &Verifier{keys: map[string]*dsa.PublicKey{}}
+	for _, opt := range options {
+		opt(v)
+	}
+
+	return v
+}
+
+// Add feeds the verifier with the next message of the stream.
+//
+// The message must have been parsed with the rfc5424.WithRaw option, since its original octets are needed.
+// It returns an error for malformed signature or certificate blocks, which are reported as tampered.
+func (v *Verifier) Add(m *rfc5424.SyslogMessage) error {
+	if m == nil || m.Raw == nil {
+		return ErrNoRaw
+	}
+
+	e := entry{message: m}
+	var err error
+	if m.StructuredData != nil {
+		if _, ok := (*m.StructuredData)[SignatureBlockID]; ok {
+			e.sb, err = parseSignatureBlock(m)
+		} else if _, ok := (*m.StructuredData)[CertificateBlockID]; ok {
+			e.cb, err = parseCertificateBlock(m)
+		}
+	}
+	e.invalid = err != nil
+	v.entries = append(v.entries, e)
+
+	return err
+}
+
+type session struct {
+	hostname string
+	rsid     uint64
+}
+
+type group struct {
+	session
+	sg   int
+	spri int
+}
+
+// Report verifies the messages added so far.
+//
+// Since signature blocks cover the messages preceding them, the latest messages are unsigned until the next signature block.
+func (v *Verifier) Report() *Report {
+	statuses := make([]Status, len(v.entries))
+	for i, e := range v.entries {
+		if e.invalid {
+			statuses[i] = Tampered
+		}
+	}
+
+	keys := v.verifyCertificateBlocks(statuses)
+
+	// Verify the signature blocks, ignoring the redundant ones
+	type counter struct {
+		group
+		gbc uint64
+	}
+	seen := map[counter]bool{}
+	var valid []int
+	for i, e := range v.entries {
+		if e.sb == nil {
+			continue
+		}
+		key := v.key(e.sb.session(), keys)
+		if key == nil {
+			continue
+		}
+		if !verify(key, e.sb.hash, signedOctets(e.message, SignatureBlockID), e.sb.sign) {
+			statuses[i] = Tampered
+			continue
+		}
+		statuses[i] = Verified
+		c := counter{e.sb.group(), e.sb.gbc}
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		valid = append(valid, i)
+	}
+
+	// Match the other messages with the hashes of the verified signature blocks
+	found := map[int][]bool{}
+	for _, j := range valid {
+		found[j] = make([]bool, len(v.entries[j].sb.hashes))
+	}
+	numbers := make([][]number, len(v.entries))
+	for i, e := range v.entries {
+		if !e.plain() {
+			continue
+		}
+		sums := map[HashAlgorithm]string{}
+		for _, j := range valid {
+			sb := v.entries[j].sb
+			if sb.hostname != hostname(e.message) {
+				continue
+			}
+			h, ok := sums[sb.hash]
+			if !ok {
+				h = string(sb.hash.sum(e.message.Raw.Input))
+				sums[sb.hash] = h
+			}
+			for n, bh := range sb.hashes {
+				if string(bh) == h {
+					found[j][n] = true
+					numbers[i] = append(numbers[i], number{sb.group(), sb.fmn + uint64(n)})
+					statuses[i] = Verified
+				}
+			}
+		}
+	}
+	for i, e := range v.entries {
+		if e.plain() && statuses[i] != Verified && v.tampered(i, numbers, valid) {
+			statuses[i] = Tampered
+		}
+	}
+
+	r := &Report{}
+	for i, e := range v.entries {
+		r.Messages = append(r.Messages, Result{Message: e.message, Status: statuses[i]})
+	}
+	for _, i := range valid {
+		sb := v.entries[i].sb
+		for n, h := range sb.hashes {
+			if !found[i][n] {
+				r.Missing = append(r.Missing, Missing{
+					Hostname: sb.hostname,
+					RSID:     sb.rsid,
+					SG:       sb.sg,
+					SPRI:     sb.spri,
+					Number:   sb.fmn + uint64(n),
+					Hash:     h,
+				})
+			}
+		}
+	}
+
+	return r
+}
+
+// number is the number of a message within its signature group.
+type number struct {
+	group
+	n uint64
+}
+
+// plain tells whether the entry is a message other than a signature or a certificate block.
+func (e entry) plain() bool {
+	return e.sb == nil && e.cb == nil && !e.invalid
+}
+
+// hostname returns the HOSTNAME of the message, or an empty string when it is nil.
+func hostname(m *rfc5424.SyslogMessage) string {
+	if m.Hostname == nil {
+		return ""
+	}
+	return *m.Hostname
+}
+
+// tampered tells whether the message, not matching any hash, must be one of the messages covered by the verified signature blocks.
+//
+// Within each signature group the message could belong to, its number is after the ones of the messages and of the blocks preceding it,
+// and before the ones of the messages and of the blocks following it.
+// The message has been modified or forged when all these numbers are covered by verified signature blocks (or when there are none).
+// Otherwise it is not verifiable: eg., its signature block has been lost, or it has been sent before signing began.
+func (v *Verifier) tampered(i int, numbers [][]number, valid []int) bool {
+	m := v.entries[i].message
+	done := map[group]bool{}
+	for _, j := range valid {
+		sb := v.entries[j].sb
+		g := sb.group()
+		if done[g] || sb.hostname != hostname(m) || !v.covers(sb, m, valid) {
+			continue
+		}
+		done[g] = true
+
+		var lo, hi uint64
+		hasLo, hasHi := false, false
+		below := func(n uint64) {
+			if !hasLo || n > lo {
+				lo, hasLo = n, true
+			}
+		}
+		above := func(n uint64) {
+			if !hasHi || n < hi {
+				hi, hasHi = n, true
+			}
+		}
+		var blocks []*signatureBlock
+		for _, k := range valid {
+			o := v.entries[k].sb
+			if o.group() != g {
+				continue
+			}
+			blocks = append(blocks, o)
+			// Blocks follow the messages they cover
+			if k < i {
+				below(o.fmn + uint64(len(o.hashes)) - 1)
+			} else {
+				above(o.fmn + uint64(len(o.hashes)))
+			}
+		}
+		for k, ns := range numbers {
+			for _, n := range ns {
+				if n.group != g || k == i {
+					continue
+				}
+				if k < i {
+					below(n.n)
+				} else {
+					above(n.n)
+				}
+			}
+		}
+		if !hasLo || !hasHi {
+			continue
+		}
+		if lo+1 >= hi || covered(blocks, lo+1, hi) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// covered tells whether the signature blocks cover the message numbers from first (included) to last (excluded).
+func covered(blocks []*signatureBlock, first, last uint64) bool {
+	for progress := true; progress && first < last; {
+		progress = false
+		for _, b := range blocks {
+			if b.fmn <= first && first < b.fmn+uint64(len(b.hashes)) {
+				first = b.fmn + uint64(len(b.hashes))
+				progress = true
+			}
+		}
+	}
+
+	return first >= last
+}
+
+// verifyCertificateBlocks verifies the certificate blocks, returning the keys they carry when the option to use them is on.
+func (v *Verifier) verifyCertificateBlocks(statuses []Status) map[session]*dsa.PublicKey {
+	groups := map[group][]int{}
+	var order []group
+	for i, e := range v.entries {
+		if e.cb == nil {
+			continue
+		}
+		g := e.cb.group()
+		if _, ok := groups[g]; !ok {
+			order = append(order, g)
+		}
+		groups[g] = append(groups[g], i)
+	}
+
+	keys := map[session]*dsa.PublicKey{}
+	for _, g := range order {
+		indices := groups[g]
+		key := v.key(g.session, nil)
+		if key == nil && v.certificates {
+			payload, ok := v.payload(indices)
+			if !ok {
+				continue
+			}
+			var err error
+			if key, err = parsePayload(payload, v.roots); err != nil {
+				for _, i := range indices {
+					statuses[i] = Tampered
+				}
+				continue
+			}
+		}
+		if key == nil {
+			continue
+		}
+
+		verified := true
+		for _, i := range indices {
+			e := v.entries[i]
+			if verify(key, e.cb.hash, signedOctets(e.message, CertificateBlockID), e.cb.sign) {
+				statuses[i] = Verified
+			} else {
+				statuses[i] = Tampered
+				verified = false
+			}
+		}
+		if verified && v.certificates {
+			keys[g.session] = key
+		}
+	}
+
+	return keys
+}
+
+// payload reassembles the payload block from its fragments, if all of them have been received.
+func (v *Verifier) payload(indices []int) (string, bool) {
+	blocks := make([]*certificateBlock, 0, len(indices))
+	for _, i := range indices {
+		blocks = append(blocks, v.entries[i].cb)
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].index < blocks[j].index
+	})
+
+	tpbl := blocks[0].tpbl
+	payload := make([]byte, 0, len(blocks[0].frag))
+	for _, b := range blocks {
+		if b.tpbl != tpbl {
+			return "", false
+		}
+		// Fragments can be sent more than once
+		if b.index <= len(payload) {
+			continue
+		}
+		if b.index != len(payload)+1 {
+			return "", false
+		}
+		payload = append(payload, b.frag...)
+	}
+	if len(payload) != tpbl {
+		return "", false
+	}
+
+	return string(payload), true
+}
+
+// key returns the key for the given session, either the local one or the one sent in certificate blocks.
+func (v *Verifier) key(s session, keys map[session]*dsa.PublicKey) *dsa.PublicKey {
+	if key, ok := v.keys[s.hostname]; ok {
+		return key
+	}
+	if key, ok := v.keys[""]; ok {
+		return key
+	}
+
+	return keys[s]
+}
+
+// covers tells whether the message belongs to the signature group of the signature block (RFC5848 section 4.2.3).
+func (v *Verifier) covers(sb *signatureBlock, m *rfc5424.SyslogMessage, valid []int) bool {
+	if m.Priority == nil {
+		return false
+	}
+	pri := int(*m.Priority)
+	switch sb.sg {
+	case 0:
+		return true
+	case 1:
+		return pri == sb.spri
+	case 2:
+		// Each group contains the priorities greater than the one of the previous group up to its own
+		lower := -1
+		for _, i := range valid {
+			o := v.entries[i].sb
+			if o.session() == sb.session() && o.sg == 2 && o.spri < sb.spri && o.spri > lower {
+				lower = o.spri
+			}
+		}
+		return pri > lower && pri <= sb.spri
+	}
+
+	// Custom groups
+	return false
+}
+
+func (b *block) session() session {
+	return session{hostname: b.hostname, rsid: b.rsid}
+}
+
+func (b *block) group() group {
+	return group{session: b.session(), sg: b.sg, spri: b.spri}
+}
@@ -4,6 +4,7 @@ package syslog
 
 import (
 	"io"
+	"strings"
 	"time"
 
 	"github.com/influxdata/go-syslog/v3/common"
@@ -49,6 +50,23 @@ type Result struct {
 	Error   error
 }
 
+// Errors represents all the errors a machine encountered while parsing a single syslog message.
+type Errors []error
+
+// Error joins the messages of the errors.
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
 // Message represent a minimal syslog message.
 type Message interface {
 	Valid() bool
@@ -61,6 +79,32 @@ type Message interface {
 	ComputeFromPriority(value uint8)
 }
 
+// Accessor represents a syslog message whose parts can be read regardless of its format.
+//
+// Absent parts are nil.
+type Accessor interface {
+	Message
+
+	// Format returns the format of the message, eg. "rfc5424" or "rfc3164".
+	Format() string
+	GetPriority() *uint8
+	GetTimestamp() *time.Time
+	GetHostname() *string
+	GetAppname() *string
+	GetProcID() *string
+	GetMsgID() *string
+	GetMessage() *string
+	GetStructuredData() *map[string]map[string]string
+}
+
+// Span represents the position of a part of a syslog message within the input it has been parsed from.
+//
+// Start is inclusive while End is exclusive, so that the part is input[Start:End].
+type Span struct {
+	Start int
+	End   int
+}
+
 // Base represents a base struct for syslog messages.
 //
 // It contains the fields in common among different formats.
@@ -150,3 +194,38 @@ func (m *Base) SeverityShortLevel() *string {
 
 	return nil
 }
+
+// GetPriority returns the priority.
+func (m *Base) GetPriority() *uint8 {
+	return m.Priority
+}
+
+// GetTimestamp returns the timestamp.
+func (m *Base) GetTimestamp() *time.Time {
+	return m.Timestamp
+}
+
+// GetHostname returns the hostname.
+func (m *Base) GetHostname() *string {
+	return m.Hostname
+}
+
+// GetAppname returns the app-name.
+func (m *Base) GetAppname() *string {
+	return m.Appname
+}
+
+// GetProcID returns the process ID.
+func (m *Base) GetProcID() *string {
+	return m.ProcID
+}
+
+// GetMsgID returns the message ID.
+func (m *Base) GetMsgID() *string {
+	return m.MsgID
+}
+
+// GetMessage returns the message.
+func (m *Base) GetMessage() *string {
+	return m.Message
+}
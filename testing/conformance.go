@@ -0,0 +1,126 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3"
+)
+
+// OptionsDirective is the prefix of the line of a corpus listing the options to parse its samples with.
+const OptionsDirective = "# options:"
+
+// ConformanceResult is the expected outcome of parsing a sample, as stored in the golden files.
+type ConformanceResult struct {
+	Input   string         `json:"input"`
+	Message syslog.Message `json:"message,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// Conformance parses the corpora in the directory and compares the results with their golden files.
+//
+// A corpus is a file with the .log extension containing one sample per line.
+// Empty lines and lines starting with # are ignored,
+// except for the one starting with OptionsDirective, whose space-separated words are given to newMachine.
+// The golden file of a corpus has the same name, with the .json extension,
+// and contains the JSON array of the results of its samples.
+//
+// When update is true the golden files are (re)written rather than compared.
+func Conformance(t *testing.T, dir string, update bool, newMachine func(options []string) (syslog.Machine, error)) {
+	t.Helper()
+
+	corpora, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corpora) == 0 {
+		t.Fatalf("no corpora in %s", dir)
+	}
+
+	for _, corpus := range corpora {
+		corpus := corpus
+		name := strings.TrimSuffix(filepath.Base(corpus), ".log")
+		t.Run(name, func(t *testing.T) {
+			data, err := ioutil.ReadFile(corpus)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var options []string
+			var samples []string
+			for _, line := range strings.Split(string(data), "\n") {
+				switch {
+				case strings.HasPrefix(line, OptionsDirective):
+					options = strings.Fields(strings.TrimPrefix(line, OptionsDirective))
+				case line == "" || strings.HasPrefix(line, "#"):
+				default:
+					samples = append(samples, line)
+				}
+			}
+			m, err := newMachine(options)
+			if err != nil {
+				t.Fatalf("options %q: %v", options, err)
+			}
+
+			results := make([]ConformanceResult, len(samples))
+			for i, sample := range samples {
+				results[i].Input = sample
+				msg, err := m.Parse([]byte(sample))
+				if msg != nil {
+					results[i].Message = msg
+				}
+				if err != nil {
+					results[i].Error = err.Error()
+				}
+			}
+
+			golden := strings.TrimSuffix(corpus, ".log") + ".json"
+			if update {
+				if err := ioutil.WriteFile(golden, encode(t, results), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			data, err = ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run the tests with the update flag to create it)", err)
+			}
+			var expected []json.RawMessage
+			if err := json.Unmarshal(data, &expected); err != nil {
+				t.Fatalf("%s: %v", golden, err)
+			}
+			if len(expected) != len(results) {
+				t.Fatalf("%s has %d results, the corpus %d samples", golden, len(expected), len(results))
+			}
+			for i, r := range results {
+				var want bytes.Buffer
+				if err := json.Indent(&want, expected[i], "", "  "); err != nil {
+					t.Fatal(err)
+				}
+				if got := encode(t, r); !bytes.Equal(bytes.TrimSpace(got), want.Bytes()) {
+					t.Errorf("sample %d %q\ngot:\n%s\nwant:\n%s", i+1, r.Input, got, want.Bytes())
+				}
+			}
+		})
+	}
+}
+
+// encode returns the indented JSON representation of v, without escaping the HTML characters.
+func encode(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	e.SetIndent("", "  ")
+	if err := e.Encode(v); err != nil {
+		t.Fatal(err)
+	}
+
+	return b.Bytes()
+}
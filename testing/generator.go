@@ -0,0 +1,287 @@
+package testing
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault is the part of a syslog message that a Generator makes invalid.
+type Fault int
+
+const (
+	// NoFault makes the Generator produce valid messages.
+	NoFault Fault = iota
+	// PriorityFault produces a priority value out of the 0-191 range.
+	PriorityFault
+	// VersionFault produces a zero version (RFC5424 only).
+	VersionFault
+	// TimestampFault produces a timestamp with a non-existent month.
+	TimestampFault
+	// HostnameFault produces a hostname longer than 255 characters.
+	HostnameFault
+	// AppnameFault produces an app-name longer than 48 characters (RFC5424 only).
+	AppnameFault
+	// ProcIDFault produces a proc-id longer than 128 characters (RFC5424 only).
+	ProcIDFault
+	// MsgIDFault produces a msg-id longer than 32 characters (RFC5424 only).
+	MsgIDFault
+	// StructuredDataFault produces a structured data element having a parameter without name (RFC5424 only).
+	StructuredDataFault
+	// MessageFault produces a message containing a control character (RFC3164 only).
+	MessageFault
+)
+
+var (
+	// RFC5424Faults contains the faults that the RFC5424 messages can have.
+	RFC5424Faults = []Fault{PriorityFault, VersionFault, TimestampFault, HostnameFault, AppnameFault, ProcIDFault, MsgIDFault, StructuredDataFault}
+	// RFC3164Faults contains the faults that the RFC3164 messages can have.
+	RFC3164Faults = []Fault{PriorityFault, TimestampFault, HostnameFault, MessageFault}
+)
+
+const (
+	// printusascii are the visible US-ASCII characters - ie., from 33 to 126.
+	printusascii = "!\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+	// sdname are the characters allowed in SD-IDs and PARAM-NAMEs.
+	sdname = "!#$%&'()*+,-./0123456789:;<>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\^_`abcdefghijklmnopqrstuvwxyz{|}~"
+	alnum  = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// text contains the pieces free-form texts are composed of.
+var text = []string{
+	"a", "e", "i", "o", "u", "s", "t", "n", "r", "l", "0", "7", " ", " ", ".", ",", ":", "-", "=", "[", "]", "\"", "\\", "é", "ß", "日本", "€", "🙂",
+}
+
+// Generator produces random syslog messages.
+//
+// The messages only depend on the source of the Generator, so that a seeded source gives reproducible messages.
+// They never contain LF and NUL characters, thus they can be framed with any non-transparent trailer.
+type Generator struct {
+	r *rand.Rand
+}
+
+// NewGenerator creates a Generator drawing random values from the given source.
+func NewGenerator(src rand.Source) *Generator {
+	return &Generator{r: rand.New(src)}
+}
+
+// RFC5424 returns a RFC5424 syslog message, having the given fault.
+//
+// It panics when the fault is not in RFC5424Faults, nor it is NoFault.
+func (g *Generator) RFC5424(fault Fault) []byte {
+	check(fault, RFC5424Faults)
+
+	var b strings.Builder
+	b.WriteString("<" + strconv.Itoa(g.priority(fault)) + ">")
+	if fault == VersionFault {
+		b.WriteString("0")
+	} else {
+		b.WriteString(strconv.Itoa(1 + g.r.Intn(999)))
+	}
+	b.WriteString(" " + g.rfc3339(fault == TimestampFault))
+	b.WriteString(" " + g.field(printusascii, 255, fault == HostnameFault))
+	b.WriteString(" " + g.field(printusascii, 48, fault == AppnameFault))
+	b.WriteString(" " + g.field(printusascii, 128, fault == ProcIDFault))
+	b.WriteString(" " + g.field(printusascii, 32, fault == MsgIDFault))
+	b.WriteString(" " + g.structuredData(fault == StructuredDataFault))
+	if g.r.Intn(4) > 0 {
+		b.WriteString(" ")
+		if g.r.Intn(2) == 0 {
+			b.WriteString("\xEF\xBB\xBF")
+		}
+		b.WriteString(g.text(0, 200))
+	}
+
+	return []byte(b.String())
+}
+
+// RFC3164 returns a RFC3164 syslog message, having the given fault.
+//
+// It panics when the fault is not in RFC3164Faults, nor it is NoFault.
+func (g *Generator) RFC3164(fault Fault) []byte {
+	check(fault, RFC3164Faults)
+
+	var b strings.Builder
+	b.WriteString("<" + strconv.Itoa(g.priority(fault)) + ">")
+	stamp := g.time().Format(time.Stamp)
+	if fault == TimestampFault {
+		stamp = "Jxn" + stamp[3:]
+	}
+	b.WriteString(stamp)
+	hostname := g.token(printusascii, 1+g.r.Intn(32))
+	if fault == HostnameFault {
+		hostname = g.token(printusascii, 256)
+	}
+	b.WriteString(" " + hostname + " ")
+	// TAG[PID]: CONTENT
+	if g.r.Intn(4) > 0 {
+		b.WriteString(g.token(alnum, 1+g.r.Intn(32)))
+		if g.r.Intn(2) == 0 {
+			b.WriteString("[" + strconv.Itoa(g.r.Intn(1<<22)) + "]")
+		}
+		b.WriteString(": ")
+	}
+	msg := g.text(1, 200)
+	if fault == MessageFault {
+		i := g.r.Intn(len(msg) + 1)
+		msg = msg[:i] + "\x01" + msg[i:]
+	}
+	b.WriteString(msg)
+
+	return []byte(b.String())
+}
+
+// OctetCounting frames the messages with the octet counting technique - ie., it prefixes each of them with its length and a space.
+func OctetCounting(messages ...[]byte) []byte {
+	var out []byte
+	for _, m := range messages {
+		out = append(out, strconv.Itoa(len(m))...)
+		out = append(out, ' ')
+		out = append(out, m...)
+	}
+
+	return out
+}
+
+// NonTransparent frames the messages with the non-transparent technique - ie., it follows each of them by the trailer (LF or NUL).
+func NonTransparent(trailer byte, messages ...[]byte) []byte {
+	var out []byte
+	for _, m := range messages {
+		out = append(out, m...)
+		out = append(out, trailer)
+	}
+
+	return out
+}
+
+func check(fault Fault, faults []Fault) {
+	if fault == NoFault {
+		return
+	}
+	for _, f := range faults {
+		if f == fault {
+			return
+		}
+	}
+	panic(fmt.Sprintf("fault %d not applicable", fault))
+}
+
+func (g *Generator) priority(fault Fault) int {
+	if fault == PriorityFault {
+		return 192 + g.r.Intn(808)
+	}
+
+	return g.r.Intn(192)
+}
+
+// time returns a time between 2000 and 2037 (excluded).
+func (g *Generator) time() time.Time {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	end := time.Date(2037, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	return time.Unix(start+g.r.Int63n(end-start), 0).UTC()
+}
+
+// rfc3339 returns a RFC3339 timestamp with up to microseconds, or a NILVALUE.
+func (g *Generator) rfc3339(invalid bool) string {
+	if !invalid && g.r.Intn(8) == 0 {
+		return "-"
+	}
+	ts := g.time().Format("2006-01-02T15:04:05")
+	if invalid {
+		ts = ts[:5] + "13" + ts[7:]
+	}
+	if digits := g.r.Intn(7); digits > 0 {
+		ts += "." + fmt.Sprintf("%06d", g.r.Intn(1000000))[:digits]
+	}
+	if g.r.Intn(3) == 0 {
+		return ts + "Z"
+	}
+	sign := "+"
+	if g.r.Intn(2) == 0 {
+		sign = "-"
+	}
+
+	return ts + fmt.Sprintf("%s%02d:%02d", sign, g.r.Intn(24), g.r.Intn(60))
+}
+
+// field returns a header field up to max characters long, or a NILVALUE.
+//
+// Invalid fields are one character longer than max.
+func (g *Generator) field(alphabet string, max int, invalid bool) string {
+	if invalid {
+		return g.token(alphabet, max+1)
+	}
+	if g.r.Intn(4) == 0 {
+		return "-"
+	}
+
+	return g.token(alphabet, g.length(max))
+}
+
+// length returns a random length up to max, favouring short ones.
+func (g *Generator) length(max int) int {
+	if max > 16 && g.r.Intn(8) > 0 {
+		max = 16
+	}
+
+	return 1 + g.r.Intn(max)
+}
+
+// token returns a string of n characters of the alphabet.
+func (g *Generator) token(alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[g.r.Intn(len(alphabet))]
+	}
+
+	return string(b)
+}
+
+// text returns a free-form UTF-8 text from min to max pieces long.
+func (g *Generator) text(min, max int) string {
+	var b strings.Builder
+	for n := min + g.r.Intn(max-min+1); n > 0; n-- {
+		b.WriteString(text[g.r.Intn(len(text))])
+	}
+
+	return b.String()
+}
+
+// structuredData returns up to 3 structured data elements, having unique SD-IDs, or a NILVALUE.
+func (g *Generator) structuredData(invalid bool) string {
+	n := g.r.Intn(4)
+	if invalid && n == 0 {
+		n = 1
+	}
+	if n == 0 {
+		return "-"
+	}
+
+	var b strings.Builder
+	ids := map[string]bool{}
+	for len(ids) < n {
+		id := g.token(sdname, g.length(32))
+		if ids[id] {
+			continue
+		}
+		ids[id] = true
+
+		b.WriteString("[" + id)
+		for p := g.r.Intn(4); p > 0; p-- {
+			b.WriteString(" " + g.token(sdname, g.length(32)) + "=\"")
+			// Escape the delimiters of the PARAM-VALUE
+			b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(g.text(0, 20)))
+			b.WriteString("\"")
+		}
+		if invalid && len(ids) == n {
+			// PARAM-NAME missing
+			b.WriteString(` =""`)
+		}
+		b.WriteString("]")
+	}
+
+	return b.String()
+}
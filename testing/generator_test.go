@@ -0,0 +1,90 @@
+package testing
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/nontransparent"
+	"github.com/influxdata/go-syslog/v3/octetcounting"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorReproducible(t *testing.T) {
+	g1 := NewGenerator(rand.NewSource(42))
+	g2 := NewGenerator(rand.NewSource(42))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, g1.RFC5424(NoFault), g2.RFC5424(NoFault))
+		assert.Equal(t, g1.RFC3164(NoFault), g2.RFC3164(NoFault))
+	}
+}
+
+func TestGeneratorRFC5424(t *testing.T) {
+	g := NewGenerator(rand.NewSource(5424))
+	for i := 0; i < 500; i++ {
+		input := g.RFC5424(NoFault)
+		_, err := rfc5424.NewMachine(rfc5424.WithCompliantMsg()).Parse(input)
+		assert.Nil(t, err, "%q", input)
+
+		for _, f := range RFC5424Faults {
+			input := g.RFC5424(f)
+			_, err := rfc5424.NewMachine().Parse(input)
+			assert.Error(t, err, "fault %d: %q", f, input)
+		}
+	}
+}
+
+func TestGeneratorRFC3164(t *testing.T) {
+	g := NewGenerator(rand.NewSource(3164))
+	for i := 0; i < 500; i++ {
+		input := g.RFC3164(NoFault)
+		_, err := rfc3164.NewMachine().Parse(input)
+		assert.Nil(t, err, "%q", input)
+
+		for _, f := range RFC3164Faults {
+			input := g.RFC3164(f)
+			_, err := rfc3164.NewMachine().Parse(input)
+			assert.Error(t, err, "fault %d: %q", f, input)
+		}
+	}
+}
+
+func TestGeneratorNotApplicableFault(t *testing.T) {
+	g := NewGenerator(rand.NewSource(1))
+	assert.Panics(t, func() { g.RFC3164(VersionFault) })
+	assert.Panics(t, func() { g.RFC5424(MessageFault) })
+}
+
+func TestFramings(t *testing.T) {
+	g := NewGenerator(rand.NewSource(6587))
+	messages := make([][]byte, 100)
+	for i := range messages {
+		messages[i] = g.RFC5424(NoFault)
+	}
+
+	results := func(p syslog.Parser, input []byte) []syslog.Result {
+		res := []syslog.Result{}
+		p.WithListener(func(r *syslog.Result) {
+			res = append(res, *r)
+		})
+		p.Parse(bytes.NewReader(input))
+		return res
+	}
+	streams := map[string][]syslog.Result{
+		"octet counting":      results(octetcounting.NewParser(), OctetCounting(messages...)),
+		"non transparent/LF":  results(nontransparent.NewParser(), NonTransparent('\n', messages...)),
+		"non transparent/NUL": results(nontransparent.NewParser(nontransparent.WithTrailer(nontransparent.NUL)), NonTransparent(0, messages...)),
+	}
+	for name, res := range streams {
+		t.Run(name, func(t *testing.T) {
+			assert.Len(t, res, len(messages))
+			for i, r := range res {
+				assert.Nil(t, r.Error, fmt.Sprintf("%q", messages[i]))
+			}
+		})
+	}
+}
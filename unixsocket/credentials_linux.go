@@ -0,0 +1,55 @@
+package unixsocket
+
+import (
+	"net"
+	"syscall"
+)
+
+var oobSize = syscall.CmsgSpace(syscall.SizeofUcred)
+
+// enableCredentials makes the kernel attach the credentials of the sending processes to the datagrams (SO_PASSCRED).
+func enableCredentials(c *net.UnixConn) error {
+	raw, err := c.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var serr error
+	if err := raw.Control(func(fd uintptr) {
+		serr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_PASSCRED, 1)
+	}); err != nil {
+		return err
+	}
+
+	return serr
+}
+
+// readCredentials returns the credentials in the control messages of a datagram.
+func readCredentials(oob []byte) *Credentials {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil
+	}
+	for i := range msgs {
+		if ucred, err := syscall.ParseUnixCredentials(&msgs[i]); err == nil {
+			return &Credentials{PID: int(ucred.Pid), UID: ucred.Uid, GID: ucred.Gid}
+		}
+	}
+
+	return nil
+}
+
+// peerCredentials returns the credentials of the process that connected to a stream socket (SO_PEERCRED).
+func peerCredentials(c *net.UnixConn) *Credentials {
+	raw, err := c.SyscallConn()
+	if err != nil {
+		return nil
+	}
+	var out *Credentials
+	raw.Control(func(fd uintptr) {
+		if ucred, err := syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED); err == nil {
+			out = &Credentials{PID: int(ucred.Pid), UID: ucred.Uid, GID: ucred.Gid}
+		}
+	})
+
+	return out
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+// +build !linux
+
+package unixsocket
+
+import (
+	"net"
+)
+
+// Credentials are only available on Linux.
+const oobSize = 0
+
+func enableCredentials(c *net.UnixConn) error {
+	return nil
+}
+
+func readCredentials(oob []byte) *Credentials {
+	return nil
+}
+
+func peerCredentials(c *net.UnixConn) *Credentials {
+	return nil
+}
@@ -0,0 +1,380 @@
+// Package unixsocket receives syslog messages from unix sockets, like the /dev/log socket local daemons write to.
+//
+// It supports both datagram (unixgram) sockets, where every datagram is a message,
+// and stream (unix) sockets, where messages are terminated by a new line or a NUL character.
+// On Linux the results carry the credentials (PID, UID, and GID) of the sending processes.
+package unixsocket
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+)
+
+// DevLog is the path of the socket the syslog(3) function writes to.
+const DevLog = "/dev/log"
+
+const defaultMaxMessageLength = 65536
+
+var (
+	// ErrNetwork is returned for networks other than unixgram and unix.
+	ErrNetwork = errors.New("expecting a unixgram or a unix network")
+	// ErrInUse is returned when another process is listening on the socket.
+	ErrInUse = errors.New("socket already in use")
+	// ErrNotSocket is returned when the path exists but it is not a socket.
+	ErrNotSocket = errors.New("path exists and it is not a socket")
+)
+
+// Credentials represents the credentials of the process that sent a message.
+type Credentials struct {
+	PID int
+	UID uint32
+	GID uint32
+}
+
+// Result represents the outcome of the parsing of a message received from the socket.
+type Result struct {
+	syslog.Result
+	// Credentials is nil when they are not available (eg., on systems other than Linux).
+	Credentials *Credentials
+}
+
+// Handler receives the results.
+//
+// It is never called concurrently, not even for the many connections of stream sockets.
+type Handler func(res *Result)
+
+// Option represents an option for the Listener.
+type Option func(*Listener)
+
+// WithMachine sets the machine parsing the messages (a best effort RFC3164 parser assuming the current year by default).
+func WithMachine(m syslog.Machine) Option {
+	return func(l *Listener) {
+		if m != nil {
+			l.machine = m
+		}
+	}
+}
+
+// WithPermissions sets the permissions of the socket file (0666 by default, so that any process can write to it).
+func WithPermissions(perm os.FileMode) Option {
+	return func(l *Listener) {
+		l.perm = perm.Perm()
+	}
+}
+
+// WithHostname sets the hostname inserted into the RFC3164 messages (the one reported by the kernel by default).
+//
+// Since the messages written to local sockets lack the HOSTNAME, the listener inserts it after their TIMESTAMP before parsing them,
+// otherwise their TAG would be mistaken for a hostname. The messages already having one (ie., followed by a TAG) are left alone.
+// An empty hostname disables the insertion.
+func WithHostname(hostname string) Option {
+	return func(l *Listener) {
+		l.hostname = hostname
+	}
+}
+
+// WithMaxMessageLength sets the maximum length of the messages (64KiB by default).
+//
+// Longer datagrams are truncated, while longer messages received from stream sockets make the listener close their connections.
+func WithMaxMessageLength(length int) Option {
+	return func(l *Listener) {
+		if length > 0 {
+			l.maxLength = length
+		}
+	}
+}
+
+// Listener receives syslog messages from a unix socket.
+type Listener struct {
+	network   string
+	path      string
+	machine   syslog.Machine
+	perm      os.FileMode
+	hostname  string
+	maxLength int
+
+	packet *net.UnixConn
+	stream *net.UnixListener
+
+	mu     sync.Mutex // Serializes the parsing and the handler calls
+	connMu sync.Mutex
+	conns  map[*net.UnixConn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Listen creates the socket file at path and listens on it.
+//
+// The network must be unixgram (as /dev/log usually is) or unix.
+// A stale socket file at path (ie., one nobody is listening on) is replaced,
+// while paths starting with @ are Linux abstract sockets, which have no file.
+func Listen(network, path string, options ...Option) (*Listener, error) {
+	if network != "unixgram" && network != "unix" {
+		return nil, ErrNetwork
+	}
+
+	l := &Listener{
+		network:   network,
+		path:      path,
+		machine:   rfc3164.NewParser(rfc3164.WithYear(rfc3164.CurrentYear{}), rfc3164.WithBestEffort()),
+		perm:      0666,
+		maxLength: defaultMaxMessageLength,
+		conns:     map[*net.UnixConn]struct{}{},
+	}
+	l.hostname, _ = os.Hostname()
+	for _, opt := range options {
+		opt(l)
+	}
+
+	if !l.abstract() {
+		if err := removeStale(network, path); err != nil {
+			return nil, err
+		}
+	}
+
+	addr := &net.UnixAddr{Name: path, Net: network}
+	var err error
+	if network == "unixgram" {
+		l.packet, err = net.ListenUnixgram(network, addr)
+	} else {
+		l.stream, err = net.ListenUnix(network, addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if l.packet != nil {
+		err = enableCredentials(l.packet)
+	} else {
+		// The socket file is removed by Close, together with the ones of datagram sockets
+		l.stream.SetUnlinkOnClose(false)
+	}
+	if err == nil && !l.abstract() {
+		err = os.Chmod(path, l.perm)
+	}
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Addr returns the address of the socket.
+func (l *Listener) Addr() net.Addr {
+	if l.packet != nil {
+		return l.packet.LocalAddr()
+	}
+
+	return l.stream.Addr()
+}
+
+// Serve receives the messages and calls the handler with the outcome of their parsing, until the listener is closed.
+//
+// It returns nil when the listener has been closed, the error that stopped it otherwise.
+func (l *Listener) Serve(h Handler) error {
+	if l.packet != nil {
+		return l.servePacket(h)
+	}
+
+	return l.serveStream(h)
+}
+
+// Close stops the listener, closes its connections, and removes the socket file.
+func (l *Listener) Close() error {
+	l.connMu.Lock()
+	if l.closed {
+		l.connMu.Unlock()
+		return nil
+	}
+	l.closed = true
+	for c := range l.conns {
+		c.Close()
+	}
+	l.connMu.Unlock()
+
+	var err error
+	if l.packet != nil {
+		err = l.packet.Close()
+	}
+	if l.stream != nil {
+		err = l.stream.Close()
+	}
+	l.wg.Wait()
+
+	if !l.abstract() {
+		if rerr := os.Remove(l.path); err == nil && rerr != nil && !os.IsNotExist(rerr) {
+			err = rerr
+		}
+	}
+
+	return err
+}
+
+func (l *Listener) isClosed() bool {
+	l.connMu.Lock()
+	defer l.connMu.Unlock()
+
+	return l.closed
+}
+
+func (l *Listener) abstract() bool {
+	return len(l.path) > 0 && l.path[0] == '@'
+}
+
+func (l *Listener) servePacket(h Handler) error {
+	buf := make([]byte, l.maxLength)
+	oob := make([]byte, oobSize)
+	for {
+		n, oobn, _, _, err := l.packet.ReadMsgUnix(buf, oob)
+		if err != nil {
+			if l.isClosed() {
+				return nil
+			}
+			return err
+		}
+		l.process(h, buf[:n], readCredentials(oob[:oobn]))
+	}
+}
+
+func (l *Listener) serveStream(h Handler) error {
+	for {
+		c, err := l.stream.AcceptUnix()
+		if err != nil {
+			if l.isClosed() {
+				l.wg.Wait()
+				return nil
+			}
+			return err
+		}
+
+		l.connMu.Lock()
+		if l.closed {
+			l.connMu.Unlock()
+			c.Close()
+			continue
+		}
+		l.conns[c] = struct{}{}
+		l.wg.Add(1)
+		l.connMu.Unlock()
+
+		go l.serveConn(h, c)
+	}
+}
+
+func (l *Listener) serveConn(h Handler, c *net.UnixConn) {
+	defer func() {
+		l.connMu.Lock()
+		delete(l.conns, c)
+		l.connMu.Unlock()
+		c.Close()
+		l.wg.Done()
+	}()
+
+	creds := peerCredentials(c)
+	scanner := bufio.NewScanner(c)
+	size := 4096
+	if size > l.maxLength {
+		size = l.maxLength
+	}
+	scanner.Buffer(make([]byte, 0, size), l.maxLength)
+	scanner.Split(splitMessages)
+	for scanner.Scan() {
+		l.process(h, scanner.Bytes(), creds)
+	}
+	if err := scanner.Err(); err != nil && !l.isClosed() {
+		l.mu.Lock()
+		h(&Result{Result: syslog.Result{Error: err}, Credentials: creds})
+		l.mu.Unlock()
+	}
+}
+
+func (l *Listener) process(h Handler, input []byte, creds *Credentials) {
+	input = bytes.TrimRight(input, "\n\x00")
+	if len(input) == 0 {
+		return
+	}
+	input = insertHostname(input, l.hostname)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	msg, err := l.machine.Parse(input)
+	h(&Result{Result: syslog.Result{Message: msg, Error: err}, Credentials: creds})
+}
+
+// splitMessages splits the stream at new lines and NUL characters.
+func splitMessages(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\n\x00"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+// stamp matches the TIMESTAMP forms the RFC3164 machine accepts, followed by a space:
+// "Mmm dd hh:mm:ss", optionally with a 4-digit year before the time or with fractional seconds, and RFC3339 timestamps.
+var stamp = regexp.MustCompile(`^(?:[A-Za-z]{3} [ 0-9][0-9] (?:[0-9]{4} )?[0-9]{2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]{1,9})?|` +
+	`[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}(?:Z|[+-][0-9]{2}:[0-9]{2})) `)
+
+// tag matches a TAG, with its optional CONTENT, followed by a colon (eg., "sshd[42]: ").
+var tag = regexp.MustCompile(`^[^ :\[]{1,32}(?:\[[^\]]*\])?:(?: |$)`)
+
+// insertHostname inserts the hostname after the TIMESTAMP of RFC3164 messages (eg., "<PRI>Mmm dd hh:mm:ss ").
+//
+// Messages already having a HOSTNAME, ie. whose TAG follows the word after the TIMESTAMP, are left alone.
+func insertHostname(input []byte, hostname string) []byte {
+	if hostname == "" {
+		return input
+	}
+	end := bytes.IndexByte(input, '>')
+	if end < 0 || end > 4 {
+		return input
+	}
+	n := len(stamp.Find(input[end+1:]))
+	if n == 0 {
+		return input
+	}
+	rest := input[end+1+n:]
+	if word := bytes.IndexByte(rest, ' '); !tag.Match(rest) && word > 0 && tag.Match(rest[word+1:]) {
+		return input
+	}
+
+	pos := end + 1 + n
+	out := make([]byte, 0, len(input)+len(hostname)+1)
+	out = append(out, input[:pos]...)
+	out = append(out, hostname...)
+	out = append(out, ' ')
+
+	return append(out, input[pos:]...)
+}
+
+// removeStale removes the socket file at path when nobody is listening on it.
+func removeStale(network, path string) error {
+	fi, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return ErrNotSocket
+	}
+	if c, err := net.Dial(network, path); err == nil {
+		c.Close()
+		return ErrInUse
+	}
+
+	return os.Remove(path)
+}
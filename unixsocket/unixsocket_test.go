@@ -0,0 +1,224 @@
+package unixsocket
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+)
+
+// tempSocket returns a path in a temporary directory, and the function removing it.
+func tempSocket(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "unixsocket")
+	assert.Nil(t, err)
+
+	return filepath.Join(dir, "log"), func() {
+		os.RemoveAll(dir)
+	}
+}
+
+// serve starts the listener and returns the channel of its results, and the function stopping it.
+func serve(t *testing.T, l *Listener) (<-chan *Result, func()) {
+	t.Helper()
+	results := make(chan *Result, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- l.Serve(func(res *Result) {
+			results <- res
+		})
+	}()
+
+	return results, func() {
+		l.Close()
+		assert.Nil(t, <-done)
+	}
+}
+
+func receive(t *testing.T, results <-chan *Result) *Result {
+	t.Helper()
+	select {
+	case res := <-results:
+		return res
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result received")
+	}
+
+	return nil
+}
+
+func assertCredentials(t *testing.T, res *Result) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		assert.Nil(t, res.Credentials)
+		return
+	}
+	assert.Equal(t, &Credentials{PID: os.Getpid(), UID: uint32(os.Getuid()), GID: uint32(os.Getgid())}, res.Credentials)
+}
+
+func TestDatagram(t *testing.T) {
+	path, remove := tempSocket(t)
+	defer remove()
+	l, err := Listen("unixgram", path, WithHostname("myhost"))
+	assert.Nil(t, err)
+	results, stop := serve(t, l)
+	defer stop()
+
+	fi, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0666), fi.Mode().Perm())
+
+	c, err := net.Dial("unixgram", path)
+	assert.Nil(t, err)
+	defer c.Close()
+	_, err = c.Write([]byte("<13>Oct 11 22:14:15 app[42]: hello\n"))
+	assert.Nil(t, err)
+
+	res := receive(t, results)
+	assert.Nil(t, res.Error)
+	m := res.Message.(*rfc3164.SyslogMessage)
+	assert.Equal(t, "myhost", *m.Hostname)
+	assert.Equal(t, "app", *m.Appname)
+	assert.Equal(t, "42", *m.ProcID)
+	assert.Equal(t, "hello", *m.Message)
+	assertCredentials(t, res)
+
+	// Datagrams already having a hostname keep it
+	_, err = c.Write([]byte("<13>Oct 11 22:14:15 otherhost app[42]: hello\n"))
+	assert.Nil(t, err)
+
+	res = receive(t, results)
+	assert.Nil(t, res.Error)
+	m = res.Message.(*rfc3164.SyslogMessage)
+	assert.Equal(t, "otherhost", *m.Hostname)
+	assert.Equal(t, "app", *m.Appname)
+	assert.Equal(t, "hello", *m.Message)
+}
+
+func TestStream(t *testing.T) {
+	path, remove := tempSocket(t)
+	defer remove()
+	l, err := Listen("unix", path, WithPermissions(0620), WithMachine(rfc5424.NewParser()))
+	assert.Nil(t, err)
+	results, stop := serve(t, l)
+	defer stop()
+
+	fi, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0620), fi.Mode().Perm())
+
+	c, err := net.Dial("unix", path)
+	assert.Nil(t, err)
+	defer c.Close()
+	_, err = c.Write([]byte("<13>1 - host app - - - first\x00<13>1 - host app - - - second\n<13>"))
+	assert.Nil(t, err)
+
+	for _, msg := range []string{"first", "second"} {
+		res := receive(t, results)
+		assert.Nil(t, res.Error)
+		assert.Equal(t, msg, *res.Message.(*rfc5424.SyslogMessage).Message)
+		assertCredentials(t, res)
+	}
+
+	// The last message ends with the connection
+	c.Close()
+	res := receive(t, results)
+	assert.Error(t, res.Error)
+}
+
+func TestStreamTooLong(t *testing.T) {
+	path, remove := tempSocket(t)
+	defer remove()
+	l, err := Listen("unix", path, WithMaxMessageLength(16))
+	assert.Nil(t, err)
+	results, stop := serve(t, l)
+	defer stop()
+
+	c, err := net.Dial("unix", l.Addr().String())
+	assert.Nil(t, err)
+	defer c.Close()
+	_, err = c.Write([]byte("<13>Oct 11 22:14:15 app: too long\n"))
+	assert.Nil(t, err)
+
+	res := receive(t, results)
+	assert.Error(t, res.Error)
+	assert.Nil(t, res.Message)
+}
+
+func TestSocketFile(t *testing.T) {
+	path, remove := tempSocket(t)
+	defer remove()
+
+	// Stale socket files are replaced
+	stale, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	assert.Nil(t, err)
+	stale.Close()
+	_, err = os.Stat(path)
+	assert.Nil(t, err)
+
+	l, err := Listen("unixgram", path)
+	assert.Nil(t, err)
+
+	// Sockets in use are not
+	_, err = Listen("unixgram", path)
+	assert.Equal(t, ErrInUse, err)
+
+	// Closing removes the socket file
+	assert.Nil(t, l.Close())
+	assert.Nil(t, l.Close())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	// Other files are left alone
+	assert.Nil(t, ioutil.WriteFile(path, []byte("data"), 0644))
+	_, err = Listen("unix", path)
+	assert.Equal(t, ErrNotSocket, err)
+
+	_, err = Listen("udp", path)
+	assert.Equal(t, ErrNetwork, err)
+}
+
+func TestInsertHostname(t *testing.T) {
+	cases := []struct {
+		input  string
+		output string
+	}{
+		{"<13>Oct 11 22:14:15 app: hello", "<13>Oct 11 22:14:15 host app: hello"},
+		{"<13>Oct  1 22:14:15 app: hello", "<13>Oct  1 22:14:15 host app: hello"},
+		{"<13>Oct 11 22:14:15.003 app: hello", "<13>Oct 11 22:14:15.003 host app: hello"},
+		{"<13>Oct 11 22:14:15.123456789 app: hello", "<13>Oct 11 22:14:15.123456789 host app: hello"},
+		{"<13>Oct 11 2003 22:14:15 app: hello", "<13>Oct 11 2003 22:14:15 host app: hello"},
+		{"<13>Oct 11 2003 22:14:15.003 app: hello", "<13>Oct 11 2003 22:14:15.003 host app: hello"},
+		{"<13>2003-10-11T22:14:15Z app: hello", "<13>2003-10-11T22:14:15Z host app: hello"},
+		{"<13>2003-10-11T22:14:15-07:00 app: hello", "<13>2003-10-11T22:14:15-07:00 host app: hello"},
+		{"<13>Oct 11 22:14:15.1234567890 app: hello", "<13>Oct 11 22:14:15.1234567890 app: hello"},
+		// Messages already having a hostname
+		{"<13>Oct 11 22:14:15 other app: hello", "<13>Oct 11 22:14:15 other app: hello"},
+		{"<13>Oct 11 22:14:15 other app[1]: hello", "<13>Oct 11 22:14:15 other app[1]: hello"},
+		{"<13>Oct 11 2003 22:14:15 other app:", "<13>Oct 11 2003 22:14:15 other app:"},
+		{"<13>Oct 11 22:14:15 app[1]: other app: hello", "<13>Oct 11 22:14:15 host app[1]: other app: hello"},
+		{"<13>1 2003-10-11T22:14:15.003Z - app - - - hello", "<13>1 2003-10-11T22:14:15.003Z - app - - - hello"},
+		{"<13>Oct 11 22:14:15", "<13>Oct 11 22:14:15"},
+		{"hello", "hello"},
+	}
+
+	m := rfc3164.NewParser(rfc3164.WithRFC3339())
+	for _, tc := range cases {
+		output := insertHostname([]byte(tc.input), "host")
+		assert.Equal(t, tc.output, string(output))
+		if tc.output != tc.input {
+			msg, err := m.Parse(output)
+			assert.Nil(t, err, tc.input)
+			assert.Equal(t, "host", *msg.(*rfc3164.SyslogMessage).Hostname, tc.input)
+			assert.Equal(t, "app", *msg.(*rfc3164.SyslogMessage).Appname, tc.input)
+		}
+	}
+	assert.Equal(t, "<13>Oct 11 22:14:15 app: hello", string(insertHostname([]byte("<13>Oct 11 22:14:15 app: hello"), "")))
+}